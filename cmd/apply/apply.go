@@ -0,0 +1,78 @@
+package apply
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/internal/client"
+	"github.com/serverscom/srvctl/internal/manifest"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
+	var (
+		files []string
+		prune bool
+	)
+	confirmOpts := &base.ConfirmOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply -f <path>",
+		Short: "Apply resources from manifests",
+		Long:  `Create missing and update changed resources described in manifest files`,
+		PersistentPreRunE: base.CombinePreRunE(
+			base.CheckOutputs(cmdContext, "text", "json", "yaml"),
+			base.CheckEmptyContexts(cmdContext),
+		),
+		Args: base.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resources, err := manifest.ReadFiles(files, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			manager := cmdContext.GetManager()
+			ctx, cancel := base.SetupContext(cmd, manager)
+			defer cancel()
+
+			base.SetupProxy(cmd, manager)
+			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
+
+			changes, err := manifest.Plan(ctx, scClient, resources, manifest.PlanOptions{Prune: prune})
+			if err != nil {
+				return err
+			}
+
+			// pruned resources are listed and confirmed before anything is applied
+			var deletions []string
+			for _, change := range changes {
+				if change.Action == manifest.ActionDelete {
+					deletions = append(deletions, fmt.Sprintf("%s (%s)", change.String(), change.ID))
+				}
+			}
+			if err := confirmOpts.ConfirmList(cmd, cmdContext, "Delete", deletions); err != nil {
+				return err
+			}
+
+			applied, applyErr := manifest.Apply(ctx, scClient, changes)
+			if errors.Is(applyErr, client.ErrDryRun) {
+				// requests of all changes are printed instead of results
+				return applyErr
+			}
+
+			formatter := cmdContext.GetOrCreateFormatter(cmd)
+			if err := formatter.FormatApplyResults(applied); err != nil {
+				return err
+			}
+			return applyErr
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&files, "filename", "f", nil, "path to manifest file or directory, '-' to read from stdin")
+	cmd.Flags().BoolVar(&prune, "prune", false, "delete resources which have a match label used in manifests but are not described in them")
+	confirmOpts.AddFlags(cmd)
+	_ = cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
@@ -0,0 +1,242 @@
+package apply
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/cmd/testutils"
+	"github.com/serverscom/srvctl/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	fixtureBasePath = filepath.Join("..", "..", "testdata", "manifests")
+	existingKeys    = []serverscom.SSHKey{
+		{
+			Name:        "existing-key",
+			Fingerprint: "existing-fingerprint",
+			Labels:      map[string]string{"env": "prod"},
+		},
+		{
+			Name:        "old-name",
+			Fingerprint: "labeled-fingerprint",
+			Labels:      map[string]string{"app": "deploy"},
+		},
+	}
+	// staleKey has the match label of a manifest but isn't described in manifests
+	staleKey = serverscom.SSHKey{
+		Name:        "stale-key",
+		Fingerprint: "stale-fingerprint",
+		Labels:      map[string]string{"app": "stale"},
+	}
+)
+
+// expectApplied expects the changes of ssh_keys.yaml to be applied
+func expectApplied(mock *mocks.MockSSHKeysService) {
+	mock.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(&serverscom.SSHKey{Name: "new-key", Fingerprint: "new-fingerprint"}, nil)
+	mock.EXPECT().
+		Update(gomock.Any(), "labeled-fingerprint", gomock.Any()).
+		Return(&serverscom.SSHKey{Name: "renamed-key", Fingerprint: "labeled-fingerprint"}, nil)
+}
+
+func TestApplyCmd(t *testing.T) {
+	testCases := []struct {
+		name           string
+		output         string
+		args           []string
+		input          string
+		configureMock  func(*mocks.MockSSHKeysService, *mocks.MockCollection[serverscom.SSHKey])
+		expectedOutput []byte
+		expectedPrompt string
+		expectedErr    error
+		expectError    bool
+	}{
+		{
+			name:           "apply yaml manifest",
+			args:           []string{"-f", filepath.Join(fixtureBasePath, "ssh_keys.yaml")},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "apply.txt")),
+			configureMock: func(mock *mocks.MockSSHKeysService, collection *mocks.MockCollection[serverscom.SSHKey]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return(existingKeys, nil)
+				mock.EXPECT().
+					Create(gomock.Any(), serverscom.SSHKeyCreateInput{
+						Name:      "new-key",
+						PublicKey: "ssh-rsa AAAAnew",
+						Labels:    map[string]string{"env": "prod"},
+					}).
+					Return(&serverscom.SSHKey{Name: "new-key", Fingerprint: "new-fingerprint"}, nil)
+				mock.EXPECT().
+					Update(gomock.Any(), "labeled-fingerprint", serverscom.SSHKeyUpdateInput{
+						Name:   "renamed-key",
+						Labels: map[string]string{"app": "deploy"},
+					}).
+					Return(&serverscom.SSHKey{Name: "renamed-key", Fingerprint: "labeled-fingerprint"}, nil)
+			},
+		},
+		{
+			name:           "apply json manifest",
+			output:         "json",
+			args:           []string{"-f", filepath.Join(fixtureBasePath, "ssh_keys.json")},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "apply.json")),
+			configureMock: func(mock *mocks.MockSSHKeysService, collection *mocks.MockCollection[serverscom.SSHKey]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return(existingKeys, nil)
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(&serverscom.SSHKey{Name: "new-key", Fingerprint: "new-fingerprint"}, nil)
+				mock.EXPECT().
+					Update(gomock.Any(), "labeled-fingerprint", gomock.Any()).
+					Return(&serverscom.SSHKey{Name: "renamed-key", Fingerprint: "labeled-fingerprint"}, nil)
+			},
+		},
+		{
+			name: "apply with create error",
+			args: []string{"-f", filepath.Join(fixtureBasePath, "ssh_keys.yaml")},
+			configureMock: func(mock *mocks.MockSSHKeysService, collection *mocks.MockCollection[serverscom.SSHKey]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return(existingKeys, nil)
+				mock.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some error"))
+			},
+			expectError: true,
+		},
+		{
+			name: "apply with list error",
+			args: []string{"-f", filepath.Join(fixtureBasePath, "ssh_keys.yaml")},
+			configureMock: func(mock *mocks.MockSSHKeysService, collection *mocks.MockCollection[serverscom.SSHKey]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return(nil, errors.New("some error"))
+			},
+			expectError: true,
+		},
+		{
+			name:           "apply with prune confirmed",
+			args:           []string{"-f", filepath.Join(fixtureBasePath, "ssh_keys.yaml"), "--prune"},
+			input:          "y\n",
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "apply_prune.txt")),
+			expectedPrompt: "  ssh-keys/app=stale (stale-fingerprint)\nDelete 1 resource(s)? [y/N]: ",
+			configureMock: func(mock *mocks.MockSSHKeysService, collection *mocks.MockCollection[serverscom.SSHKey]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return(append(existingKeys, staleKey), nil)
+				expectApplied(mock)
+				mock.EXPECT().
+					Delete(gomock.Any(), "stale-fingerprint").
+					Return(nil)
+			},
+		},
+		{
+			name:           "apply with prune declined",
+			args:           []string{"-f", filepath.Join(fixtureBasePath, "ssh_keys.yaml"), "--prune"},
+			input:          "n\n",
+			expectedPrompt: "  ssh-keys/app=stale (stale-fingerprint)\nDelete 1 resource(s)? [y/N]: ",
+			expectedErr:    base.ErrAborted,
+			configureMock: func(mock *mocks.MockSSHKeysService, collection *mocks.MockCollection[serverscom.SSHKey]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return(append(existingKeys, staleKey), nil)
+			},
+		},
+		{
+			name:           "apply with prune and yes",
+			args:           []string{"-f", filepath.Join(fixtureBasePath, "ssh_keys.yaml"), "--prune", "--yes"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "apply_prune.txt")),
+			configureMock: func(mock *mocks.MockSSHKeysService, collection *mocks.MockCollection[serverscom.SSHKey]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return(append(existingKeys, staleKey), nil)
+				expectApplied(mock)
+				mock.EXPECT().
+					Delete(gomock.Any(), "stale-fingerprint").
+					Return(nil)
+			},
+		},
+		{
+			name:        "apply without filename",
+			expectError: true,
+		},
+		{
+			name:        "apply with not existing file",
+			args:        []string{"-f", filepath.Join(fixtureBasePath, "not_existing.yaml")},
+			expectError: true,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sshServiceHandler := mocks.NewMockSSHKeysService(mockCtrl)
+	collectionHandler := mocks.NewMockCollection[serverscom.SSHKey](mockCtrl)
+
+	sshServiceHandler.EXPECT().
+		Collection().
+		Return(collectionHandler).
+		AnyTimes()
+
+	scClient := serverscom.NewClientWithEndpoint("", "")
+	scClient.SSHKeys = sshServiceHandler
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			if tc.configureMock != nil {
+				tc.configureMock(sshServiceHandler, collectionHandler)
+			}
+
+			testCmdContext := testutils.NewTestCmdContext(scClient)
+			applyCmd := NewCmd(testCmdContext)
+
+			args := []string{"apply"}
+			if len(tc.args) > 0 {
+				args = append(args, tc.args...)
+			}
+			if tc.output != "" {
+				args = append(args, "--output", tc.output)
+			}
+
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(applyCmd).
+				WithArgs(args)
+			if tc.input != "" {
+				builder = builder.WithInput(strings.NewReader(tc.input))
+			}
+
+			cmd := builder.Build()
+			var stderr bytes.Buffer
+			cmd.SetErr(&stderr)
+
+			err := cmd.Execute()
+
+			if tc.expectedPrompt != "" {
+				g.Expect(stderr.String()).To(HavePrefix(tc.expectedPrompt))
+			}
+			switch {
+			case tc.expectedErr != nil:
+				g.Expect(err).To(MatchError(tc.expectedErr))
+			case tc.expectError:
+				g.Expect(err).To(HaveOccurred())
+			default:
+				g.Expect(err).To(BeNil())
+				if tc.output == "json" {
+					g.Expect(builder.GetOutput()).To(MatchJSON(tc.expectedOutput))
+				} else {
+					g.Expect(builder.GetOutput()).To(BeEquivalentTo(string(tc.expectedOutput)))
+				}
+			}
+		})
+	}
+}
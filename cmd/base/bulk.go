@@ -0,0 +1,184 @@
+package base
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/client"
+	"github.com/serverscom/srvctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// BulkSelectFunc returns ids of resources matching label selector
+type BulkSelectFunc func(ctx context.Context, client *serverscom.Client, selector string) ([]string, error)
+
+// BulkActionFunc runs an action for a single resource
+type BulkActionFunc func(ctx context.Context, client *serverscom.Client, id string) error
+
+// BulkOptions allows action commands to run for multiple resources selected
+// by labels or read from a file instead of a single id argument
+type BulkOptions struct {
+	ConfirmOptions
+	selector    string
+	idsFrom     string
+	concurrency int
+}
+
+// AddFlags adds bulk flags to the command
+func (o *BulkOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.selector, "selector", "", "run for all resources matching label selector instead of id")
+	flags.StringVar(&o.idsFrom, "ids-from", "", "run for resources with ids from file, one per line, or '-' to read from stdin")
+	flags.IntVar(&o.concurrency, "concurrency", 5, "max number of resources processed at the same time")
+	cmd.MarkFlagsMutuallyExclusive("selector", "ids-from")
+	o.ConfirmOptions.AddFlags(cmd)
+}
+
+// IsBulk returns true if resources are selected by bulk flags
+func (o *BulkOptions) IsBulk() bool {
+	return o.selector != "" || o.idsFrom != ""
+}
+
+// Args requires an id argument unless bulk flags are used
+func (o *BulkOptions) Args(cmd *cobra.Command, args []string) error {
+	if !o.IsBulk() {
+		return cobra.ExactArgs(1)(cmd, args)
+	}
+	if len(args) > 0 {
+		return errors.New("id can't be used with --selector or --ids-from")
+	}
+	return nil
+}
+
+// Run runs action for all selected resources after confirmation and prints a result for each of them
+func (o *BulkOptions) Run(cmd *cobra.Command, cmdContext *CmdContext, action string, selectFn BulkSelectFunc, actionFn BulkActionFunc) error {
+	manager := cmdContext.GetManager()
+	SetupProxy(cmd, manager)
+
+	c := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd))
+	scClient := c.GetScClient()
+
+	ids, err := o.resolveIDs(cmd, cmdContext, scClient, selectFn)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return errors.New("no resources found")
+	}
+
+	skip, err := o.skip(cmd, cmdContext)
+	if err != nil {
+		return err
+	}
+	if !skip {
+		if o.idsFrom == "-" {
+			return errors.New("--yes is required when ids are read from stdin")
+		}
+		if err := o.confirm(cmd, fmt.Sprintf("%s %d resource(s)?", action, len(ids))); err != nil {
+			return err
+		}
+	}
+
+	dryRun := c.IsDryRun()
+
+	concurrency := o.concurrency
+	if concurrency < 1 || dryRun {
+		// requests printed in dry run mode must not interleave
+		concurrency = 1
+	}
+
+	results := make([]output.BulkResult, len(ids))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+
+			ctx, cancel := SetupContext(cmd, manager)
+			defer cancel()
+
+			results[i] = output.BulkResult{ID: id, Status: output.BulkStatusSuccess}
+			if err := actionFn(ctx, scClient, id); err != nil {
+				results[i].Status = output.BulkStatusError
+				results[i].Error = err.Error()
+			}
+		})
+	}
+	wg.Wait()
+
+	if dryRun {
+		return client.ErrDryRun
+	}
+
+	formatter := cmdContext.GetOrCreateFormatter(cmd)
+	if err := formatter.FormatBulkResults(results); err != nil {
+		return err
+	}
+
+	var failed int
+	for _, r := range results {
+		if r.Status == output.BulkStatusError {
+			failed++
+		}
+	}
+	if failed > 0 {
+		// results already explain what went wrong
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d operations failed", failed, len(results))
+	}
+	return nil
+}
+
+// resolveIDs returns ids selected by label selector or read from file
+func (o *BulkOptions) resolveIDs(cmd *cobra.Command, cmdContext *CmdContext, scClient *serverscom.Client, selectFn BulkSelectFunc) ([]string, error) {
+	if o.selector != "" {
+		ctx, cancel := SetupContext(cmd, cmdContext.GetManager())
+		defer cancel()
+		return selectFn(ctx, scClient, o.selector)
+	}
+
+	var r io.Reader
+	if o.idsFrom == "-" {
+		r = cmd.InOrStdin()
+	} else {
+		file, err := os.Open(o.idsFrom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		defer file.Close() //nolint:errcheck
+		r = file
+	}
+
+	var ids []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		id := strings.TrimSpace(scanner.Text())
+		if id == "" || strings.HasPrefix(id, "#") {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, scanner.Err()
+}
+
+// SelectIDs returns ids of all collection items matching label selector
+func SelectIDs[T any](ctx context.Context, collection serverscom.Collection[T], selector string, id func(T) string) ([]string, error) {
+	items, err := collection.SetParam("label_selector", selector).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, id(item))
+	}
+	return ids, nil
+}
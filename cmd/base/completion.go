@@ -0,0 +1,314 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/cache"
+	"github.com/serverscom/srvctl/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// completionTimeout limits time spent on fetching completions, the shell waits for them
+	completionTimeout = 5 * time.Second
+	// completionCacheTTL is a time fetched completions are reused for
+	completionCacheTTL = time.Minute
+)
+
+// CompletionItemsFunc returns completions for resources, each of them is an id
+// with a title or a name as a description
+type CompletionItemsFunc func(ctx context.Context, cmd *cobra.Command, client *serverscom.Client) ([]cobra.Completion, error)
+
+// CollectionFunc returns a collection of resources to complete or to look up names in
+type CollectionFunc[T any] func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[T], error)
+
+// CollectionCompletion returns completions for all items of a collection
+func CollectionCompletion[T any](colFn CollectionFunc[T], itemFn func(T) cobra.Completion) CompletionItemsFunc {
+	return func(ctx context.Context, cmd *cobra.Command, client *serverscom.Client) ([]cobra.Completion, error) {
+		collection, err := colFn(cmd, client)
+		if err != nil {
+			return nil, err
+		}
+		items, err := collection.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		completions := make([]cobra.Completion, 0, len(items))
+		for _, item := range items {
+			completions = append(completions, itemFn(item))
+		}
+		return completions, nil
+	}
+}
+
+// CompleteResources returns a completion function for resources returned by itemsFn.
+// Results are cached for a short time by command, name and values of set flags.
+func CompleteResources(cmdContext *CmdContext, name string, itemsFn CompletionItemsFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		items, err := fetchCompletions(cmd, cmdContext, name, itemsFn)
+		if err != nil {
+			cobra.CompDebugln(err.Error(), true)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var completions []cobra.Completion
+		for _, item := range items {
+			if strings.HasPrefix(item, toComplete) {
+				completions = append(completions, item)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// CompleteIDs returns a completion function for the first positional argument
+// which lists ids of collection items
+func CompleteIDs[T any](cmdContext *CmdContext, colFn CollectionFunc[T], itemFn func(T) cobra.Completion) cobra.CompletionFunc {
+	complete := CompleteResources(cmdContext, "args", CollectionCompletion(colFn, itemFn))
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// SetIDCompletion sets fn as a completion function for subcommands of cmd
+// which take an id as the first argument and have no completion yet
+func SetIDCompletion(cmd *cobra.Command, fn cobra.CompletionFunc) {
+	for _, c := range cmd.Commands() {
+		SetIDCompletion(c, fn)
+
+		if !takesID(c) || c.ValidArgsFunction != nil {
+			continue
+		}
+		c.ValidArgsFunction = fn
+	}
+}
+
+// takesID checks if cmd takes an id as the first argument, e.g. "get <id>"
+func takesID(cmd *cobra.Command) bool {
+	fields := strings.Fields(cmd.Use)
+	return len(fields) > 1 && strings.HasPrefix(fields[1], "<")
+}
+
+// RegisterFlagCompletions registers completion functions for flags with
+// resource ids in cmd and all its subcommands
+func RegisterFlagCompletions(cmd *cobra.Command, cmdContext *CmdContext) {
+	for name, itemsFn := range flagCompletions {
+		if cmd.Flags().Lookup(name) == nil {
+			continue
+		}
+		if _, ok := cmd.GetFlagCompletionFunc(name); ok {
+			continue
+		}
+		_ = cmd.RegisterFlagCompletionFunc(name, CompleteResources(cmdContext, name, itemsFn))
+	}
+
+	for _, c := range cmd.Commands() {
+		RegisterFlagCompletions(c, cmdContext)
+	}
+}
+
+// fetchCompletions returns cached completions or fetches them with itemsFn
+func fetchCompletions(cmd *cobra.Command, cmdContext *CmdContext, name string, itemsFn CompletionItemsFunc) ([]cobra.Completion, error) {
+	if err := checkCompletionContext(cmd, cmdContext.GetManager()); err != nil {
+		return nil, err
+	}
+
+	key := completionKey(cmd, name)
+	c := completionCache(cmd, cmdContext)
+
+	var items []cobra.Completion
+	if c != nil && c.Get(key, &items) {
+		return items, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	manager := cmdContext.GetManager()
+	SetupProxy(cmd, manager)
+	scClient := cmdContext.GetClient().GetScClient()
+
+	items, err := itemsFn(ctx, cmd, scClient)
+	if err != nil {
+		return nil, err
+	}
+	if c != nil {
+		_ = c.Set(key, items)
+	}
+	return items, nil
+}
+
+// checkCompletionContext checks that the config is loaded and the context
+// completions are fetched for has a token, so the shell doesn't wait for
+// requests which fail anyway
+func checkCompletionContext(cmd *cobra.Command, manager *config.Manager) error {
+	if manager == nil {
+		return errors.New("config is not loaded")
+	}
+	name, err := cmd.Flags().GetString("context")
+	if err != nil || name == "" {
+		name = manager.GetDefaultContextName()
+	}
+	if name == "" {
+		return errors.New(ErrNoContexts)
+	}
+	ctx, err := manager.GetContext(name)
+	if err != nil {
+		return err
+	}
+	if ctx.Token == "" && ctx.TokenFile == "" && ctx.TokenCommand == "" {
+		return fmt.Errorf("context %q has no token", name)
+	}
+	return nil
+}
+
+// completionCache returns cache for completions, nil if cache is disabled
+func completionCache(cmd *cobra.Command, cmdContext *CmdContext) *cache.Cache {
+	noCache, err := cmdContext.GetManager().GetResolvedBoolValue(cmd, "no-cache")
+	if err != nil || noCache {
+		return nil
+	}
+	c, err := NewCache(cmd, cmdContext)
+	if err != nil || c == nil || c.TTL() <= 0 {
+		return nil
+	}
+	return cache.New(c.Dir(), completionCacheTTL)
+}
+
+// completionKey returns cache key for completions, flags are included since
+// completions can depend on them, e.g. server models depend on location
+func completionKey(cmd *cobra.Command, name string) string {
+	key := fmt.Sprintf("completion %s %s", cmd.CommandPath(), name)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		key += fmt.Sprintf(" --%s=%s", f.Name, f.Value.String())
+	})
+	return key
+}
+
+// flagCompletions maps flags with resource ids to functions completing them
+var flagCompletions = map[string]CompletionItemsFunc{
+	"location-id":         completeLocations,
+	"server-model-id":     completeServerModels,
+	"sbm-flavor-model-id": completeSBMFlavors,
+	"operating-system-id": completeOperatingSystems,
+	"uplink-model-id":     completeUplinkModels,
+	"region-id":           completeCloudRegions,
+	"volume-id":           completeCloudVolumes,
+	"rack-id":             completeRacks,
+}
+
+// int64Flag returns value of a flag other completions depend on
+func int64Flag(cmd *cobra.Command, name string) (int64, error) {
+	f := cmd.Flags().Lookup(name)
+	if f == nil || !f.Changed {
+		return 0, fmt.Errorf("--%s is required for completion", name)
+	}
+	return strconv.ParseInt(f.Value.String(), 10, 64)
+}
+
+// Int64Completion returns completion for int64 id with description
+func Int64Completion(id int64, desc string) cobra.Completion {
+	return cobra.CompletionWithDesc(strconv.FormatInt(id, 10), desc)
+}
+
+var completeLocations = CollectionCompletion(
+	func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.Location], error) {
+		return client.Locations.Collection(), nil
+	},
+	func(l serverscom.Location) cobra.Completion { return Int64Completion(l.ID, l.Name) },
+)
+
+var completeServerModels = CollectionCompletion(
+	func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.ServerModelOption], error) {
+		locationID, err := int64Flag(cmd, "location-id")
+		if err != nil {
+			return nil, err
+		}
+		return client.Locations.ServerModelOptions(locationID), nil
+	},
+	func(m serverscom.ServerModelOption) cobra.Completion { return Int64Completion(m.ID, m.Name) },
+)
+
+var completeSBMFlavors = CollectionCompletion(
+	func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.SBMFlavor], error) {
+		locationID, err := int64Flag(cmd, "location-id")
+		if err != nil {
+			return nil, err
+		}
+		return client.Locations.SBMFlavorOptions(locationID), nil
+	},
+	func(f serverscom.SBMFlavor) cobra.Completion { return Int64Completion(f.ID, f.Name) },
+)
+
+// completeOperatingSystems completes SBM operating systems if the command has
+// an SBM flavor flag, otherwise operating systems of a server model
+var completeOperatingSystems = CollectionCompletion(
+	func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.OperatingSystemOption], error) {
+		locationID, err := int64Flag(cmd, "location-id")
+		if err != nil {
+			return nil, err
+		}
+		if cmd.Flags().Lookup("sbm-flavor-model-id") != nil {
+			flavorID, err := int64Flag(cmd, "sbm-flavor-model-id")
+			if err != nil {
+				return nil, err
+			}
+			return client.Locations.SBMOperatingSystemOptions(locationID, flavorID), nil
+		}
+		serverModelID, err := int64Flag(cmd, "server-model-id")
+		if err != nil {
+			return nil, err
+		}
+		return client.Locations.OperatingSystemOptions(locationID, serverModelID), nil
+	},
+	func(o serverscom.OperatingSystemOption) cobra.Completion { return Int64Completion(o.ID, o.FullName) },
+)
+
+var completeUplinkModels = CollectionCompletion(
+	func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.UplinkOption], error) {
+		locationID, err := int64Flag(cmd, "location-id")
+		if err != nil {
+			return nil, err
+		}
+		serverModelID, err := int64Flag(cmd, "server-model-id")
+		if err != nil {
+			return nil, err
+		}
+		return client.Locations.UplinkOptions(locationID, serverModelID), nil
+	},
+	func(u serverscom.UplinkOption) cobra.Completion { return Int64Completion(u.ID, u.Name) },
+)
+
+var completeCloudRegions = CollectionCompletion(
+	func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.CloudComputingRegion], error) {
+		return client.CloudComputingRegions.Collection(), nil
+	},
+	func(r serverscom.CloudComputingRegion) cobra.Completion { return Int64Completion(r.ID, r.Name) },
+)
+
+var completeCloudVolumes = CollectionCompletion(
+	func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.CloudBlockStorageVolume], error) {
+		return client.CloudBlockStorageVolumes.Collection(), nil
+	},
+	func(v serverscom.CloudBlockStorageVolume) cobra.Completion {
+		return cobra.CompletionWithDesc(v.ID, v.Name)
+	},
+)
+
+var completeRacks = CollectionCompletion(
+	func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.Rack], error) {
+		return client.Racks.Collection(), nil
+	},
+	func(r serverscom.Rack) cobra.Completion { return cobra.CompletionWithDesc(r.ID, r.Name) },
+)
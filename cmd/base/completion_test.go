@@ -0,0 +1,67 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/client"
+	"github.com/serverscom/srvctl/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func TestCompleteResources(t *testing.T) {
+	testCases := []struct {
+		name                string
+		config              *config.Config
+		expectedCompletions []cobra.Completion
+		expectFetch         bool
+	}{
+		{
+			name: "complete with token",
+			config: &config.Config{
+				DefaultContext: "default",
+				Contexts:       []config.Context{{Name: "default", Token: "secret"}},
+			},
+			expectedCompletions: []cobra.Completion{"web-01", "web-02"},
+			expectFetch:         true,
+		},
+		{
+			name:   "complete without contexts",
+			config: &config.Config{},
+		},
+		{
+			name: "complete with context without token",
+			config: &config.Config{
+				DefaultContext: "default",
+				Contexts:       []config.Context{{Name: "default"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cmd := &cobra.Command{Use: "get"}
+			AddGlobalFlags(cmd)
+			g.Expect(cmd.ParseFlags(nil)).To(Succeed())
+
+			manager := config.NewManagerWithConfig(tc.config)
+			cmdContext := NewCmdContext(manager, client.NewWithClient(serverscom.NewClientWithEndpoint("", "")))
+
+			fetched := false
+			complete := CompleteResources(cmdContext, "args", func(ctx context.Context, cmd *cobra.Command, client *serverscom.Client) ([]cobra.Completion, error) {
+				fetched = true
+				return []cobra.Completion{"web-01", "web-02", "db-01"}, nil
+			})
+
+			completions, directive := complete(cmd, nil, "web")
+
+			g.Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+			g.Expect(completions).To(Equal(tc.expectedCompletions))
+			g.Expect(fetched).To(Equal(tc.expectFetch))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package base
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	// ErrAborted is returned when user doesn't confirm an action
+	ErrAborted = errors.New("aborted")
+	// ErrConfirmationRequired is returned when confirmation can't be asked
+	ErrConfirmationRequired = errors.New("confirmation is required but stdin is not a terminal, use --yes to skip it")
+)
+
+// ResourceGetFunc returns a resource by id
+type ResourceGetFunc func(ctx context.Context, client *serverscom.Client, id string) (any, error)
+
+// ConfirmOptions asks for confirmation before destructive actions unless
+// --yes flag is set or confirmation is disabled with 'confirm' option
+type ConfirmOptions struct {
+	yes bool
+}
+
+// AddFlags adds confirmation flags to the command
+func (o *ConfirmOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&o.yes, "yes", "y", false, "don't ask for confirmation")
+}
+
+// ConfirmResource fetches a resource to show its title and location and asks to confirm action for it
+func (o *ConfirmOptions) ConfirmResource(cmd *cobra.Command, cmdContext *CmdContext, action, resourceName, id string, get ResourceGetFunc) error {
+	if skip, err := o.skip(cmd, cmdContext); err != nil || skip {
+		return err
+	}
+	// fail before fetching a resource if there is no one to ask
+	if !isInteractive(cmd.InOrStdin()) {
+		return ErrConfirmationRequired
+	}
+
+	manager := cmdContext.GetManager()
+	ctx, cancel := SetupContext(cmd, manager)
+	defer cancel()
+
+	SetupProxy(cmd, manager)
+	scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
+
+	resource, err := get(ctx, scClient, id)
+	if err != nil {
+		return err
+	}
+
+	return o.confirm(cmd, fmt.Sprintf("%s %s %s?", action, resourceName, describeResource(id, resource)))
+}
+
+// ConfirmList lists resources on stderr and asks to confirm action for all of them at once
+func (o *ConfirmOptions) ConfirmList(cmd *cobra.Command, cmdContext *CmdContext, action string, resources []string) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	if skip, err := o.skip(cmd, cmdContext); err != nil || skip {
+		return err
+	}
+	if !isInteractive(cmd.InOrStdin()) {
+		return ErrConfirmationRequired
+	}
+
+	for _, r := range resources {
+		if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "  %s\n", r); err != nil {
+			return err
+		}
+	}
+	return o.confirm(cmd, fmt.Sprintf("%s %d resource(s)?", action, len(resources)))
+}
+
+// skip returns true if confirmation isn't needed. Confirmation is required
+// if the 'confirm' option can't be resolved, the error is returned then.
+func (o *ConfirmOptions) skip(cmd *cobra.Command, cmdContext *CmdContext) (bool, error) {
+	// nothing is changed in dry run mode
+	if o.yes || cmdContext.GetClient().IsDryRun() {
+		return true, nil
+	}
+	required, err := cmdContext.GetManager().GetResolvedBoolValue(cmd, "confirm")
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve confirm option: %w", err)
+	}
+	return !required, nil
+}
+
+// confirm asks to confirm an action, returns error if it isn't confirmed
+func (o *ConfirmOptions) confirm(cmd *cobra.Command, prompt string) error {
+	if !isInteractive(cmd.InOrStdin()) {
+		return ErrConfirmationRequired
+	}
+
+	ok, err := Confirm(cmd, prompt)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAborted
+	}
+	return nil
+}
+
+// Confirm prints prompt to stderr and reads an answer from stdin,
+// returns true if user answered yes
+func Confirm(cmd *cobra.Command, prompt string) (bool, error) {
+	if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "%s [y/N]: ", prompt); err != nil {
+		return false, err
+	}
+
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// isInteractive returns true if answers can be read from r. Input which is
+// not a file is set explicitly, so it's considered interactive.
+func isInteractive(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return true
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// describeResource returns resource title and location for confirmation prompt
+func describeResource(id string, resource any) string {
+	var fields map[string]any
+	if data, err := json.Marshal(resource); err == nil {
+		_ = json.Unmarshal(data, &fields)
+	}
+
+	details := "ID: " + id
+	if location := firstField(fields, "location_code", "region_code", "location_group_code"); location != "" {
+		details += ", location: " + location
+	}
+
+	title := firstField(fields, "title", "name", "cidr")
+	if title == "" {
+		return fmt.Sprintf("(%s)", details)
+	}
+	return fmt.Sprintf("%q (%s)", title, details)
+}
+
+// firstField returns the first non-empty string value of the keys
+func firstField(fields map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := fields[key].(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
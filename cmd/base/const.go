@@ -4,3 +4,6 @@ const (
 	ENDPOINT      = "https://api.servers.com/v1"
 	ErrNoContexts = "no contexts found, log in first: 'srvctl login <context-name>' or, if you don't want to create the context, you may pass SC_TOKEN every time you use srvctl"
 )
+
+// annotationLocal marks commands which don't call the API, see SetLocal
+const annotationLocal = "srvctl/local"
@@ -0,0 +1,12 @@
+package base
+
+// ExitCodeError is returned by commands which should exit with a specific
+// exit code, e.g. to signal a result to scripts
+type ExitCodeError struct {
+	Code    int
+	Message string
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,69 @@
+package base
+
+import (
+	"strings"
+
+	"github.com/serverscom/srvctl/internal/output/entities"
+	"github.com/serverscom/srvctl/internal/output/filter"
+	"github.com/spf13/cobra"
+)
+
+// FilterOptions filters and sorts fetched list items by entity fields on the client side
+type FilterOptions[T any] struct {
+	filters    []string
+	sortBy     string
+	conditions []*filter.Condition
+	sortField  *entities.Field
+	sortDesc   bool
+}
+
+// AddFlags adds filter flags to the command
+func (o *FilterOptions[T]) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringArrayVar(&o.filters, "filter", nil, "Filter fetched items by a field, can be specified multiple times: 'Status=active'. Operators: =, !=, ~ (regexp), <, <=, >, >= (numbers and dates)")
+	flags.StringVar(&o.sortBy, "sort-by", "", "Sort fetched items by a field, prefix it with - for descending order")
+}
+
+// Parse parses filters and the sort field against the entity of T,
+// so invalid ones are reported before items are fetched
+func (o *FilterOptions[T]) Parse() error {
+	if len(o.filters) == 0 && o.sortBy == "" {
+		return nil
+	}
+
+	entity, err := entities.Registry.GetEntityFromValue([]T{})
+	if err != nil {
+		return err
+	}
+
+	if o.conditions, err = filter.Parse(entity, o.filters); err != nil {
+		return err
+	}
+
+	if o.sortBy != "" {
+		name, desc := strings.CutPrefix(o.sortBy, "-")
+		if o.sortField, err = entity.GetField(name); err != nil {
+			return err
+		}
+		o.sortDesc = desc
+	}
+	return nil
+}
+
+// Sorted reports whether items are sorted, so all of them are needed before output
+func (o *FilterOptions[T]) Sorted() bool {
+	return o.sortBy != ""
+}
+
+// Apply returns items satisfying filters, sorted by the sort field
+func (o *FilterOptions[T]) Apply(items []T) ([]T, error) {
+	items, err := filter.Apply(items, o.conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.sortField == nil {
+		return items, nil
+	}
+	return items, filter.Sort(items, o.sortField, o.sortDesc)
+}
@@ -1,6 +1,9 @@
 package base
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/serverscom/srvctl/internal/config"
+	"github.com/spf13/cobra"
+)
 
 func AddGlobalFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().String("config", "", "config file path")
@@ -8,10 +11,25 @@ func AddGlobalFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().String("proxy", "", "proxy url")
 	cmd.PersistentFlags().Int("http-timeout", 30, "HTTP timeout ( seconds )")
 	cmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
-	cmd.PersistentFlags().StringP("output", "o", "text", "output format (text/json/yaml)")
+	cmd.PersistentFlags().StringP("output", "o", "text", "output format (text/json/ndjson/yaml/csv/tsv/jsonpath=<expr>/jq=<expr>)")
 	// define help flag without shorthand before cobra adds it by default to avoid conflict with no-header flag shorthand
 	cmd.PersistentFlags().Bool("help", false, "Print usage")
 	cmd.PersistentFlags().BoolP("no-header", "h", false, "print output without headers")
+	cmd.PersistentFlags().Bool("dry-run", false, "print requests of mutating commands instead of sending them")
+	cmd.PersistentFlags().Bool("confirm", true, "ask for confirmation before destroying resources")
+	cmd.PersistentFlags().Bool("no-cache", false, "don't use cached catalog responses")
+	cmd.PersistentFlags().Int("cache-ttl", 24, "catalog cache TTL ( hours ), 0 disables cache")
+	cmd.PersistentFlags().Int("retries", 3, "max retries of requests failed with network errors, 429 or 5xx responses, 0 disables retries")
+	cmd.PersistentFlags().Int("retry-max-wait", 30, "max wait between retries ( seconds ), longer Retry-After isn't waited for")
+	cmd.PersistentFlags().Bool("retry-non-idempotent", false, "retry POST and PATCH requests too, they may be applied twice")
+	cmd.PersistentFlags().Int("parallel", 1, "number of pages fetched concurrently by list commands with --all")
+
+	// every global flag but help can be set by SRVCTL_<FLAG> env var
+	config.BindEnv(cmd.PersistentFlags(),
+		"config", "context", "proxy", "http-timeout", "verbose", "output",
+		"no-header", "dry-run", "confirm", "no-cache", "cache-ttl",
+		"retries", "retry-max-wait", "retry-non-idempotent", "parallel",
+	)
 }
 
 func AddFormatFlags(cmd *cobra.Command) {
@@ -19,4 +37,6 @@ func AddFormatFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().Bool("field-list", false, "list available fields")
 	cmd.PersistentFlags().Bool("page-view", false, "use page view format")
 	cmd.PersistentFlags().StringP("template", "t", "", "go template string to output in specified format")
+
+	config.BindEnv(cmd.PersistentFlags(), "field", "field-list", "page-view", "template")
 }
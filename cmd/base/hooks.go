@@ -3,12 +3,15 @@ package base
 import (
 	"errors"
 	"fmt"
-	"html/template"
+	"net/http"
 	"os"
 	"slices"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/serverscom/srvctl/internal/client"
+	"github.com/serverscom/srvctl/internal/config"
 	"github.com/serverscom/srvctl/internal/output"
 	"github.com/serverscom/srvctl/internal/output/entities"
 	"github.com/spf13/cobra"
@@ -29,6 +32,11 @@ func CombinePreRunE(funcs ...func(cmd *cobra.Command, args []string) error) func
 // InitCmdContext inits cmd context and sets up necessary dependencies
 func InitCmdContext(cmdContext *CmdContext) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
+		// flags are read directly below, so env vars are applied to them first
+		if err := config.ApplyEnv(cmd.Flags()); err != nil {
+			return err
+		}
+
 		configPath, err := cmd.Flags().GetString("config")
 		if err != nil {
 			return err
@@ -49,23 +57,125 @@ func InitCmdContext(cmdContext *CmdContext) func(cmd *cobra.Command, args []stri
 			}
 		}
 
+		// token helpers may be slow or interactive, so they run only for commands calling the API
+		var token string
+		if !isLocal(cmd) {
+			if token, err = m.GetToken(context); err != nil {
+				return err
+			}
+		}
+
+		endpoint := m.GetEndpoint(context)
+
 		c := client.NewClient(
-			m.GetToken(context),
-			m.GetEndpoint(context),
+			token,
+			endpoint,
 		)
 		version := cmd.Root().Version
 		c.SetUserAgent(userAgent(version))
+		if !isLocal(cmd) {
+			if err := SetupRetries(cmd, m, c); err != nil {
+				return err
+			}
+		}
 
 		cmdContext.manager = m
 		cmdContext.client = c
 		cmdContext.formatter = output.NewFormatter(cmd, m)
 
-		return nil
+		if err := SetupCache(cmd, cmdContext); err != nil {
+			return err
+		}
+		return SetupDryRun(cmd, cmdContext)
+	}
+}
+
+// SetLocal marks cmd and its subcommands as working with the local config only,
+// tokens of contexts aren't resolved for them
+func SetLocal(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[annotationLocal] = "true"
+}
+
+// isLocal checks if cmd or one of its parents is marked by SetLocal
+func isLocal(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[annotationLocal] == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupRetries makes client retry requests failed with network errors, timeouts,
+// 429 or 5xx responses according to 'retries', 'retry-max-wait' and
+// 'retry-non-idempotent' flags. 'http-timeout' limits every attempt.
+func SetupRetries(cmd *cobra.Command, manager *config.Manager, c *client.Client) error {
+	retries, err := manager.GetResolvedIntValue(cmd, "retries")
+	if err != nil {
+		return err
+	}
+	maxWait, err := manager.GetResolvedIntValue(cmd, "retry-max-wait")
+	if err != nil {
+		return err
 	}
+	nonIdempotent, err := manager.GetResolvedBoolValue(cmd, "retry-non-idempotent")
+	if err != nil {
+		return err
+	}
+	httpTimeout, err := manager.GetResolvedIntValue(cmd, "http-timeout")
+	if err != nil {
+		return err
+	}
+
+	transport := client.NewRetryTransport(http.DefaultTransport, client.RetryPolicy{
+		Retries:            retries,
+		MaxWait:            time.Duration(maxWait) * time.Second,
+		RetryNonIdempotent: nonIdempotent,
+		AttemptTimeout:     time.Duration(httpTimeout) * time.Second,
+	})
+	// response headers are read outside of the retries, from the final response only
+	c.SetHTTPClient(&http.Client{Transport: client.NewResponseHeaderTransport(transport)})
+	return nil
+}
+
+// SetupCache makes client cache catalog responses unless 'no-cache' flag is set or cache TTL is 0
+func SetupCache(cmd *cobra.Command, cmdContext *CmdContext) error {
+	noCache, err := cmdContext.GetManager().GetResolvedBoolValue(cmd, "no-cache")
+	if err != nil || noCache {
+		return err
+	}
+
+	c, err := NewCache(cmd, cmdContext)
+	if err != nil || c == nil || c.TTL() <= 0 {
+		return err
+	}
+	cmdContext.GetClient().SetCache(c)
+	return nil
+}
+
+// SetupDryRun makes client print mutating requests instead of sending them if 'dry-run' flag is set
+func SetupDryRun(cmd *cobra.Command, cmdContext *CmdContext) error {
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil || !dryRun {
+		return err
+	}
+
+	cmdContext.GetClient().SetDryRun(func(req *client.Request) error {
+		// request is printed instead of a result, so client.ErrDryRun is not an error for user
+		cmd.SilenceErrors = true
+		return cmdContext.GetOrCreateFormatter(cmd).FormatRequest(req)
+	})
+	return nil
 }
 
 // defaultPassThroughOutputs are output formats printed as is by most commands
-var defaultPassThroughOutputs = []string{"json", "yaml"}
+var defaultPassThroughOutputs = []string{"json", "ndjson", "yaml", "jsonpath", "jq"}
+
+// tableOutputs are output formats printing entity fields as columns
+var tableOutputs = []string{"text", "csv", "tsv"}
 
 // CheckFormatterFlags checks flags related to formatter
 func CheckFormatterFlags(cmdContext *CmdContext, entities map[string]entities.EntityInterface) func(cmd *cobra.Command, args []string) error {
@@ -73,7 +183,7 @@ func CheckFormatterFlags(cmdContext *CmdContext, entities map[string]entities.En
 }
 
 // CheckFormatterFlagsWithOutputs checks flags related to formatter, allowing the
-// "text" output plus the given pass-through outputs, that need no further checks
+// table outputs plus the given pass-through outputs, that need no further checks
 func CheckFormatterFlagsWithOutputs(cmdContext *CmdContext, entities map[string]entities.EntityInterface, passThroughOutputs []string) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		if entities == nil {
@@ -97,17 +207,21 @@ func CheckFormatterFlagsWithOutputs(cmdContext *CmdContext, entities map[string]
 		}
 
 		output := formatter.GetOutput()
-		if output != "text" {
+		if !slices.Contains(tableOutputs, output) {
 			if slices.Contains(passThroughOutputs, output) {
-				return nil
+				return formatter.CompileExpression()
 			}
-			allowed := append(slices.Clone(passThroughOutputs), "text")
+			allowed := append(slices.Clone(passThroughOutputs), tableOutputs...)
 			slices.Sort(allowed)
 			return fmt.Errorf("invalid output %q, allowed values: %s", output, strings.Join(allowed, ", "))
 		}
 
 		tmpl := formatter.GetTemplateStr()
-		if tmpl != "" {
+		if output != "text" {
+			if tmpl != "" || formatter.GetPageView() {
+				return fmt.Errorf("--template and --page-view can't be used with the %s output", output)
+			}
+		} else if tmpl != "" {
 			tmpl = strings.Trim(tmpl, " ")
 			r := strings.NewReplacer(`\t`, "\t", `\n`, "\n")
 			tmpl = r.Replace(tmpl)
@@ -164,3 +278,17 @@ func CheckEmptyContexts(cmdContext *CmdContext) func(cmd *cobra.Command, args []
 		return nil
 	}
 }
+
+// CheckOutputs returns error if output is not one of the allowed outputs.
+// It's used by commands which don't format entities.
+func CheckOutputs(cmdContext *CmdContext, outputs ...string) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		output := cmdContext.GetOrCreateFormatter(cmd).GetOutput()
+		if slices.Contains(outputs, output) {
+			return nil
+		}
+		allowed := slices.Clone(outputs)
+		slices.Sort(allowed)
+		return fmt.Errorf("invalid output %q, allowed values: %s", output, strings.Join(allowed, ", "))
+	}
+}
@@ -1,9 +1,22 @@
 package base
 
 import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/client"
+	"github.com/serverscom/srvctl/internal/config"
+	"github.com/spf13/cobra"
 )
 
 func TestValidateFieldPrefixConsistency(t *testing.T) {
@@ -14,3 +27,370 @@ func TestValidateFieldPrefixConsistency(t *testing.T) {
 	g.Expect(validateFieldPrefixConsistency([]string{"Name", "+Fingerprint"})).To(HaveOccurred())
 	g.Expect(validateFieldPrefixConsistency([]string{"+Name", "Fingerprint"})).To(HaveOccurred())
 }
+
+func TestSetupDryRun(t *testing.T) {
+	g := NewWithT(t)
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{Use: "srvctl"}
+	AddGlobalFlags(cmd)
+	cmd.SetOut(&out)
+	g.Expect(cmd.ParseFlags([]string{"--dry-run"})).To(Succeed())
+
+	var sent atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent.Add(1)
+	}))
+	defer server.Close()
+
+	manager := config.NewManagerWithConfig(&config.Config{})
+	cmdContext := NewCmdContext(manager, client.NewWithClient(serverscom.NewClientWithEndpoint("", server.URL)))
+	g.Expect(SetupDryRun(cmd, cmdContext)).To(Succeed())
+	g.Expect(cmdContext.GetClient().IsDryRun()).To(BeTrue())
+
+	req, err := http.NewRequest(http.MethodPut, server.URL+"/ssh_keys/fingerprint", strings.NewReader(`{"name":"new-name"}`))
+	g.Expect(err).To(BeNil())
+	_, err = cmdContext.GetClient().GetHTTPClient().Do(req)
+
+	g.Expect(errors.Is(err, client.ErrDryRun)).To(BeTrue())
+	g.Expect(sent.Load()).To(BeZero())
+	g.Expect(cmd.SilenceErrors).To(BeTrue())
+	g.Expect(out.String()).To(HavePrefix("PUT " + server.URL + "/ssh_keys/fingerprint\n{\n"))
+	g.Expect(out.String()).To(ContainSubstring(`"name": "new-name"`))
+}
+
+func TestSetupContext(t *testing.T) {
+	testCases := []struct {
+		name            string
+		args            []string
+		expectedTimeout time.Duration
+	}{
+		{
+			name:            "defaults",
+			expectedTimeout: 210 * time.Second,
+		},
+		{
+			name:            "without retries",
+			args:            []string{"--http-timeout", "10", "--retries", "0"},
+			expectedTimeout: 10 * time.Second,
+		},
+		{
+			name:            "with retries",
+			args:            []string{"--http-timeout", "10", "--retries", "2", "--retry-max-wait", "5"},
+			expectedTimeout: 40 * time.Second,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cmd := &cobra.Command{Use: "srvctl"}
+			AddGlobalFlags(cmd)
+			g.Expect(cmd.ParseFlags(tc.args)).To(Succeed())
+
+			ctx, cancel := SetupContext(cmd, config.NewManagerWithConfig(&config.Config{}))
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			g.Expect(ok).To(BeTrue())
+			g.Expect(deadline).To(BeTemporally("~", time.Now().Add(tc.expectedTimeout), time.Second))
+		})
+	}
+}
+
+func TestSetupCache(t *testing.T) {
+	testCases := []struct {
+		name        string
+		args        []string
+		config      config.ConfigOptions
+		expectCache bool
+	}{
+		{
+			name:        "cache by default",
+			expectCache: true,
+		},
+		{
+			name: "no cache flag",
+			args: []string{"--no-cache"},
+		},
+		{
+			name:   "no cache in config",
+			config: config.ConfigOptions{"no-cache": true},
+		},
+		{
+			name:   "cache disabled by ttl in config",
+			config: config.ConfigOptions{"cache-ttl": 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			g.Expect(os.WriteFile(configPath, []byte("defaultContext: default\ncontexts:\n  - name: default\n"), 0600)).To(Succeed())
+			manager, err := config.NewManager(configPath)
+			g.Expect(err).To(BeNil())
+			if tc.config != nil {
+				g.Expect(manager.UpdateContextConfig("default", tc.config)).To(Succeed())
+			}
+
+			cmd := &cobra.Command{Use: "srvctl"}
+			AddGlobalFlags(cmd)
+			g.Expect(cmd.ParseFlags(tc.args)).To(Succeed())
+
+			cmdContext := NewCmdContext(manager, client.NewWithClient(serverscom.NewClientWithEndpoint("", "")))
+			g.Expect(SetupCache(cmd, cmdContext)).To(Succeed())
+			g.Expect(cmdContext.GetClient().GetCache() != nil).To(Equal(tc.expectCache))
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	type item struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	notFoundErr := &serverscom.NotFoundError{StatusCode: 404, Message: "not found"}
+	stopErr := errors.New("stop")
+
+	testCases := []struct {
+		name           string
+		values         []any
+		errs           []error
+		expectedOutput string
+		expectedErr    error
+	}{
+		{
+			name: "watch list",
+			values: []any{
+				[]item{{ID: "a", Status: "new"}, {ID: "b", Status: "new"}},
+				[]item{{ID: "a", Status: "active"}, {ID: "b", Status: "new"}},
+				[]item{{ID: "a", Status: "active"}, {ID: "b", Status: "new"}},
+				[]item{{ID: "a", Status: "active"}},
+				nil,
+			},
+			errs: []error{nil, nil, nil, nil, stopErr},
+			expectedOutput: `{"type":"added","object":{"id":"a","status":"new"}}` + "\n" +
+				`{"type":"added","object":{"id":"b","status":"new"}}` + "\n" +
+				`{"type":"modified","object":{"id":"a","status":"active"}}` + "\n" +
+				`{"type":"deleted","object":{"id":"b","status":"new"}}` + "\n",
+			expectedErr: stopErr,
+		},
+		{
+			name: "watch deleted resource",
+			values: []any{
+				&item{ID: "a", Status: "active"},
+				nil,
+			},
+			errs: []error{nil, notFoundErr},
+			expectedOutput: `{"type":"added","object":{"id":"a","status":"active"}}` + "\n" +
+				`{"type":"deleted","object":{"id":"a","status":"active"}}` + "\n",
+		},
+		{
+			name:        "watch missing resource",
+			values:      []any{nil},
+			errs:        []error{notFoundErr},
+			expectedErr: notFoundErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			manager := config.NewManagerWithConfig(&config.Config{})
+			cmdContext := NewCmdContext(manager, client.NewWithClient(serverscom.NewClientWithEndpoint("", "")))
+
+			calls := 0
+			cmd := &cobra.Command{
+				Use:           "get",
+				SilenceUsage:  true,
+				SilenceErrors: true,
+				RunE: func(cmd *cobra.Command, args []string) error {
+					value, err := tc.values[calls], tc.errs[calls]
+					calls++
+					if err != nil {
+						return err
+					}
+					return cmdContext.GetOrCreateFormatter(cmd).Format(value)
+				},
+			}
+			AddGlobalFlags(cmd)
+			(&WatchOptions{}).Wrap(cmd, cmdContext)
+
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetArgs([]string{"--watch", "--interval", "1ms"})
+
+			err := cmd.Execute()
+			if tc.expectedErr != nil {
+				g.Expect(err).To(MatchError(tc.expectedErr))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+			g.Expect(out.String()).To(Equal(tc.expectedOutput))
+			g.Expect(calls).To(Equal(len(tc.values)))
+		})
+	}
+}
+
+func TestInitCmdContextToken(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	cfg := "defaultContext: helper\ncontexts:\n  - name: helper\n    endpoint: https://api.servers.com/v1\n    tokenCommand: exit 1\n"
+	if err := os.WriteFile(configPath, []byte(cfg), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name        string
+		local       bool
+		expectError bool
+	}{
+		{
+			name:        "token is resolved for API commands",
+			expectError: true,
+		},
+		{
+			name:  "token isn't resolved for local commands",
+			local: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			root := &cobra.Command{
+				Use:               "srvctl",
+				PersistentPreRunE: InitCmdContext(&CmdContext{}),
+				SilenceErrors:     true,
+				SilenceUsage:      true,
+			}
+			AddGlobalFlags(root)
+			cmd := &cobra.Command{
+				Use:  "sub",
+				RunE: func(cmd *cobra.Command, args []string) error { return nil },
+			}
+			root.AddCommand(cmd)
+			if tc.local {
+				SetLocal(cmd)
+			}
+			root.SetArgs([]string{"sub", "--config", configPath})
+
+			err := root.Execute()
+
+			if tc.expectError {
+				g.Expect(err).To(MatchError(`failed to get token of context "helper": token command "exit 1" failed: exit status 1`))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestInitCmdContextEnv(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	cfg := "defaultContext: default\ncontexts:\n  - name: default\n    endpoint: https://api.servers.com/v1\n    token: secret\n  - name: prod\n    endpoint: https://api.servers.com/v1\n    token: secret\n"
+	if err := os.WriteFile(configPath, []byte(cfg), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name            string
+		args            []string
+		env             map[string]string
+		expectedContext string
+		expectedOutput  config.ResolvedValue
+		expectedFields  []string
+		expectedError   string
+	}{
+		{
+			name: "flags are set by env",
+			env: map[string]string{
+				"SRVCTL_CONFIG":  configPath,
+				"SRVCTL_CONTEXT": "prod",
+				"SRVCTL_OUTPUT":  "json",
+				"SRVCTL_FIELD":   "ID,Name",
+			},
+			expectedContext: "prod",
+			expectedOutput:  config.ResolvedValue{Layer: config.LayerEnv, Source: "SRVCTL_OUTPUT", Value: "json"},
+			expectedFields:  []string{"ID", "Name"},
+		},
+		{
+			name: "flags override env",
+			args: []string{"--context", "default", "--output", "yaml", "--field", "ID"},
+			env: map[string]string{
+				"SRVCTL_CONFIG":  configPath,
+				"SRVCTL_CONTEXT": "prod",
+				"SRVCTL_OUTPUT":  "json",
+				"SRVCTL_FIELD":   "ID,Name",
+			},
+			expectedContext: "default",
+			expectedOutput:  config.ResolvedValue{Layer: config.LayerFlag, Source: "--output", Value: "yaml"},
+			expectedFields:  []string{"ID"},
+		},
+		{
+			name: "invalid env",
+			env: map[string]string{
+				"SRVCTL_CONFIG":    configPath,
+				"SRVCTL_NO_HEADER": "maybe",
+			},
+			expectedError: `invalid value of SRVCTL_NO_HEADER: strconv.ParseBool: parsing "maybe": invalid syntax`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			cmdContext := &CmdContext{}
+			root := &cobra.Command{
+				Use:               "srvctl",
+				PersistentPreRunE: InitCmdContext(cmdContext),
+				SilenceErrors:     true,
+				SilenceUsage:      true,
+			}
+			AddGlobalFlags(root)
+
+			var (
+				contextName string
+				output      config.ResolvedValue
+				fields      []string
+			)
+			cmd := &cobra.Command{
+				Use: "sub",
+				RunE: func(cmd *cobra.Command, args []string) error {
+					var err error
+					if contextName, err = cmd.Flags().GetString("context"); err != nil {
+						return err
+					}
+					if output, err = cmdContext.GetManager().Resolve(cmd, "output"); err != nil {
+						return err
+					}
+					fields, err = cmdContext.GetManager().GetResolvedStringSliceValue(cmd, "field")
+					return err
+				},
+			}
+			AddFormatFlags(cmd)
+			root.AddCommand(cmd)
+			root.SetArgs(append([]string{"sub"}, tc.args...))
+
+			err := root.Execute()
+
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).To(BeNil())
+			g.Expect(contextName).To(Equal(tc.expectedContext))
+			g.Expect(output).To(Equal(tc.expectedOutput))
+			g.Expect(fields).To(Equal(tc.expectedFields))
+		})
+	}
+}
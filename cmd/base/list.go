@@ -12,6 +12,7 @@ type CollectionFactory[T any] func(verbose bool, args ...string) serverscom.Coll
 
 // NewListCmd base list command for different collections
 func NewListCmd[T any](use string, entityName string, colFactory CollectionFactory[T], cmdContext *CmdContext, opts ...ListOptions[T]) *cobra.Command {
+	filterOpts := &FilterOptions[T]{}
 	aliases := []string{}
 	if use == "list" {
 		aliases = append(aliases, "ls")
@@ -22,6 +23,10 @@ func NewListCmd[T any](use string, entityName string, colFactory CollectionFacto
 		Short:   "List " + entityName,
 		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := filterOpts.Parse(); err != nil {
+				return err
+			}
+
 			manager := cmdContext.GetManager()
 
 			ctx, cancel := SetupContext(cmd, manager)
@@ -29,17 +34,35 @@ func NewListCmd[T any](use string, entityName string, colFactory CollectionFacto
 
 			SetupProxy(cmd, manager)
 
-			collection := colFactory(manager.GetVerbose(cmd), args...)
-			for _, opt := range opts {
-				opt.ApplyToCollection(collection)
+			parallel, err := manager.GetResolvedIntValue(cmd, "parallel")
+			if err != nil {
+				return err
+			}
+
+			newCollection := func() serverscom.Collection[T] {
+				collection := colFactory(manager.GetVerbose(cmd), args...)
+				for _, opt := range opts {
+					opt.ApplyToCollection(collection)
+				}
+				return collection
 			}
 
-			items, err := fetchItems(ctx, collection, opts)
+			formatter := cmdContext.GetOrCreateFormatter(cmd)
+			// rows of all pages are written as they arrive unless they have to be sorted
+			if allPages(opts) && !filterOpts.Sorted() && formatter.CanStream() {
+				return streamItems(ctx, newCollection, parallel, filterOpts, formatter)
+			}
+
+			items, err := fetchItems(ctx, newCollection, opts, parallel)
+			if err != nil {
+				return err
+			}
+
+			items, err = filterOpts.Apply(items)
 			if err != nil {
 				return err
 			}
 
-			formatter := cmdContext.GetOrCreateFormatter(cmd)
 			return formatter.Format(items)
 		},
 	}
@@ -47,6 +70,9 @@ func NewListCmd[T any](use string, entityName string, colFactory CollectionFacto
 	for _, opt := range opts {
 		opt.AddFlags(cmd)
 	}
+	filterOpts.AddFlags(cmd)
+	cmd.Flags().Bool("fixed-widths", false, "Keep column widths of the first page for the following ones when rows of all pages are written as they arrive")
+	(&WatchOptions{}).Wrap(cmd, cmdContext)
 
 	return cmd
 }
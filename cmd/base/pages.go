@@ -0,0 +1,158 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"sync"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/client"
+	"github.com/serverscom/srvctl/internal/output"
+)
+
+// collectPages fetches all pages of collections made by newCollection and passes
+// them to emit in order as soon as all previous pages are emitted.
+// The number of pages is read from the Link header of the first page response,
+// then pages 2..N are fetched by up to parallel workers. Without the header, e.g.
+// for cached pages, pages are fetched one by one until the last one.
+// The first error cancels requests in flight and is returned.
+func collectPages[T any](ctx context.Context, newCollection func() serverscom.Collection[T], parallel int, emit func([]T) error) error {
+	var header http.Header
+	first := newCollection().SetPage(1)
+	items, err := first.List(client.WithResponseHeader(ctx, &header))
+	if err != nil {
+		return err
+	}
+	if err := emit(items); err != nil {
+		return err
+	}
+
+	pageSize := len(items)
+	// cached collections return pages without fetching them, so
+	// only a fetched collection knows whether there are more pages
+	if pageSize == 0 || (!first.IsClean() && !first.HasNextPage()) {
+		return nil
+	}
+
+	lastPage, ok := lastPageNumber(header)
+	if !ok {
+		return collectNextPages(ctx, newCollection, pageSize, emit)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		pages   = make(map[int][]T)
+		next    = 1
+		emitted = 1
+	)
+
+	for range min(max(parallel, 1), lastPage-1) {
+		wg.Go(func() {
+			for {
+				mu.Lock()
+				next++
+				page := next
+				mu.Unlock()
+				if page > lastPage || ctx.Err() != nil {
+					return
+				}
+
+				pageItems, err := newCollection().SetPage(page).List(ctx)
+
+				mu.Lock()
+				if err != nil {
+					cancel(err)
+					mu.Unlock()
+					return
+				}
+
+				pages[page] = pageItems
+				for ; ctx.Err() == nil; emitted++ {
+					nextItems, ok := pages[emitted+1]
+					if !ok {
+						break
+					}
+					delete(pages, emitted+1)
+					if err := emit(nextItems); err != nil {
+						cancel(err)
+					}
+				}
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	return context.Cause(ctx)
+}
+
+// collectNextPages fetches pages after the first one one by one and passes them to emit
+// until a page is the last one or shorter than pageSize
+func collectNextPages[T any](ctx context.Context, newCollection func() serverscom.Collection[T], pageSize int, emit func([]T) error) error {
+	for page := 2; ; page++ {
+		collection := newCollection().SetPage(page)
+		items, err := collection.List(ctx)
+		if err != nil {
+			return err
+		}
+		if len(items) > 0 {
+			if err := emit(items); err != nil {
+				return err
+			}
+		}
+		if len(items) < pageSize || (!collection.IsClean() && !collection.HasNextPage()) {
+			return nil
+		}
+	}
+}
+
+// linkLastRe matches the target of the link to the last page in the Link header
+var linkLastRe = regexp.MustCompile(`<([^>]*)>[^<]*;\s*rel="?last"?`)
+
+// lastPageNumber returns the number of the last page from the Link header of a collection response
+func lastPageNumber(header http.Header) (int, bool) {
+	for _, link := range header.Values("Link") {
+		m := linkLastRe.FindStringSubmatch(link)
+		if m == nil {
+			continue
+		}
+		u, err := url.Parse(m[1])
+		if err != nil {
+			return 0, false
+		}
+		page, err := strconv.Atoi(u.Query().Get("page"))
+		if err != nil || page < 1 {
+			return 0, false
+		}
+		return page, true
+	}
+	return 0, false
+}
+
+// streamItems writes all pages of collections made by newCollection as they arrive,
+// filtered by filterOpts
+func streamItems[T any](ctx context.Context, newCollection func() serverscom.Collection[T], parallel int, filterOpts *FilterOptions[T], formatter *output.Formatter) error {
+	stream, err := formatter.NewStream([]T{})
+	if err != nil {
+		return err
+	}
+
+	err = collectPages(ctx, newCollection, parallel, func(items []T) error {
+		items, err := filterOpts.Apply(items)
+		if err != nil {
+			return err
+		}
+		return stream.Write(items)
+	})
+	if err != nil {
+		return err
+	}
+	return stream.Close()
+}
@@ -0,0 +1,195 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/client"
+	"github.com/serverscom/srvctl/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// pagedCollection makes mock collections of total items split into pages
+type pagedCollection struct {
+	ctrl     *gomock.Controller
+	total    int
+	pageSize int
+	latency  time.Duration
+	failPage int
+	cached   bool
+	noLink   bool
+
+	mu sync.Mutex
+	// maxPage is the last requested page
+	maxPage int
+}
+
+// roundTripFunc serves requests of mock collections
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// lastPage returns the number of the last page, the first one is returned even if it's empty
+func (p *pagedCollection) lastPage() int {
+	return max((p.total+p.pageSize-1)/p.pageSize, 1)
+}
+
+// receiveHeader passes the response header of a page to the transport used by the API client
+func (p *pagedCollection) receiveHeader(ctx context.Context, page int) error {
+	transport := client.NewResponseHeaderTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		if !p.noLink {
+			header.Set("Link", fmt.Sprintf(`<https://api.servers.com/v1/items?page=%d>; rel="last"`, p.lastPage()))
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader("[]"))}, nil
+	}))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://api.servers.com/v1/items?page=%d", page), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// newCollection returns a mock collection serving pages of items numbered from 0
+func (p *pagedCollection) newCollection() serverscom.Collection[int] {
+	m := mocks.NewMockCollection[int](p.ctrl)
+	page := 1
+
+	m.EXPECT().SetPage(gomock.Any()).DoAndReturn(func(n int) serverscom.Collection[int] {
+		page = n
+		return m
+	}).AnyTimes()
+	m.EXPECT().IsClean().Return(p.cached).AnyTimes()
+	m.EXPECT().HasNextPage().DoAndReturn(func() bool {
+		return !p.cached && page*p.pageSize < p.total
+	}).AnyTimes()
+	m.EXPECT().List(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]int, error) {
+		select {
+		case <-time.After(p.latency):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		p.mu.Lock()
+		p.maxPage = max(p.maxPage, page)
+		p.mu.Unlock()
+		if page == p.failPage {
+			return nil, errors.New("some error")
+		}
+		if !p.cached {
+			if err := p.receiveHeader(ctx, page); err != nil {
+				return nil, err
+			}
+		}
+
+		items := []int{}
+		for i := (page - 1) * p.pageSize; i < min(page*p.pageSize, p.total); i++ {
+			items = append(items, i)
+		}
+		return items, nil
+	}).AnyTimes()
+
+	return m
+}
+
+func TestCollectPages(t *testing.T) {
+	testCases := []struct {
+		name        string
+		total       int
+		parallel    int
+		failPage    int
+		failEmit    bool
+		cached      bool
+		noLink      bool
+		expectError bool
+	}{
+		{name: "no items", total: 0, parallel: 4},
+		{name: "single page", total: 7, parallel: 4},
+		{name: "full single page", total: 10, parallel: 4},
+		{name: "several pages", total: 95, parallel: 4},
+		{name: "full pages", total: 60, parallel: 3},
+		{name: "more workers than pages", total: 25, parallel: 16},
+		{name: "one worker", total: 25, parallel: 1},
+		{name: "cached pages", total: 45, parallel: 4, cached: true},
+		{name: "failed page", total: 95, parallel: 4, failPage: 3, expectError: true},
+		{name: "failed first page", total: 95, parallel: 4, failPage: 1, expectError: true},
+		{name: "failed emit", total: 95, parallel: 4, failEmit: true, expectError: true},
+		{name: "failed page after the last one", total: 95, parallel: 16, failPage: 11},
+		{name: "failed page after the last full one", total: 60, parallel: 16, failPage: 7},
+		{name: "no link header", total: 95, parallel: 4, noLink: true},
+		{name: "no link header with full pages", total: 60, parallel: 4, noLink: true, failPage: 7},
+		{name: "no link header with failed page", total: 95, parallel: 4, noLink: true, failPage: 3, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			p := &pagedCollection{
+				ctrl:     gomock.NewController(t),
+				total:    tc.total,
+				pageSize: 10,
+				latency:  time.Millisecond,
+				failPage: tc.failPage,
+				cached:   tc.cached,
+				noLink:   tc.noLink,
+			}
+
+			var items []int
+			err := collectPages(context.Background(), p.newCollection, tc.parallel, func(page []int) error {
+				if tc.failEmit && len(items) > 0 {
+					return errors.New("write error")
+				}
+				items = append(items, page...)
+				return nil
+			})
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).To(BeNil())
+			if !tc.cached {
+				// pages after the last one are never requested
+				g.Expect(p.maxPage).To(Equal(p.lastPage()))
+			}
+			g.Expect(items).To(HaveLen(tc.total))
+			for i, item := range items {
+				g.Expect(item).To(Equal(i))
+			}
+		})
+	}
+}
+
+func BenchmarkCollectPages(b *testing.B) {
+	for _, parallel := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("parallel=%d", parallel), func(b *testing.B) {
+			p := &pagedCollection{
+				ctrl:     gomock.NewController(b),
+				total:    2000,
+				pageSize: 100,
+				latency:  5 * time.Millisecond,
+			}
+
+			for b.Loop() {
+				err := collectPages(context.Background(), p.newCollection, parallel, func([]int) error { return nil })
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package base
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// resolvePrefixes are prefixes of arguments which are names of resources instead of ids
+var resolvePrefixes = []string{"name:", "title:"}
+
+// idRe matches arguments which can be ids of resources
+var idRe = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+// maxAmbiguousIDs limits a number of ids listed in an ambiguous match error
+const maxAmbiguousIDs = 5
+
+// ResolveItemFunc returns an id and a name or a title of a resource
+type ResolveItemFunc[T any] func(T) (id, name string)
+
+// IDResolver finds ids of resources by their names
+type IDResolver[T any] struct {
+	entityName string
+	colFn      CollectionFunc[T]
+	itemFn     ResolveItemFunc[T]
+}
+
+// NewIDResolver creates a resolver which looks up names in a collection returned by colFn
+func NewIDResolver[T any](entityName string, colFn CollectionFunc[T], itemFn ResolveItemFunc[T]) *IDResolver[T] {
+	return &IDResolver[T]{
+		entityName: entityName,
+		colFn:      colFn,
+		itemFn:     itemFn,
+	}
+}
+
+// Resolve returns an id of a resource with exactly the given name
+func (r *IDResolver[T]) Resolve(cmd *cobra.Command, cmdContext *CmdContext, name string) (string, error) {
+	ids, err := r.find(cmd, cmdContext, name)
+	if err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", fmt.Errorf("no %s found with name %q", r.entityName, name)
+	}
+	return r.unique(name, ids)
+}
+
+// find returns ids of resources with exactly the given name. The collection is
+// filtered with search_pattern which is a substring match, so names are compared after that.
+func (r *IDResolver[T]) find(cmd *cobra.Command, cmdContext *CmdContext, name string) ([]string, error) {
+	manager := cmdContext.GetManager()
+	ctx, cancel := SetupContext(cmd, manager)
+	defer cancel()
+
+	scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
+
+	collection, err := r.colFn(cmd, scClient)
+	if err != nil {
+		return nil, err
+	}
+	items, err := collection.SetParam("search_pattern", name).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, item := range items {
+		if id, itemName := r.itemFn(item); itemName == name {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// unique returns the only id or an error if the name is ambiguous
+func (r *IDResolver[T]) unique(name string, ids []string) (string, error) {
+	if len(ids) == 1 {
+		return ids[0], nil
+	}
+
+	listed := ids
+	if len(listed) > maxAmbiguousIDs {
+		listed = append(listed[:maxAmbiguousIDs:maxAmbiguousIDs], "...")
+	}
+	return "", fmt.Errorf("ambiguous name %q: matches %d %ss (%s), use an id instead",
+		name, len(ids), r.entityName, strings.Join(listed, ", "))
+}
+
+// ResolveArg returns an id for an argument. An argument with a 'name:' or 'title:'
+// prefix or one which can't be an id is resolved as a name, an id is returned as is
+// without checking it, so commands make a single request for it.
+func (r *IDResolver[T]) ResolveArg(cmd *cobra.Command, cmdContext *CmdContext, arg string) (string, error) {
+	if name, ok := trimResolvePrefix(arg); ok {
+		return r.Resolve(cmd, cmdContext, name)
+	}
+	if arg == "" || isIDShaped(arg) {
+		return arg, nil
+	}
+	return r.Resolve(cmd, cmdContext, arg)
+}
+
+// SetIDResolution makes subcommands of cmd which take an id as the first argument
+// accept a name of a resource instead. The argument is resolved by
+// IDResolver.ResolveArg before the command runs, so the command runs once with an id.
+func SetIDResolution[T any](cmd *cobra.Command, cmdContext *CmdContext, resolver *IDResolver[T]) {
+	for _, c := range cmd.Commands() {
+		SetIDResolution(c, cmdContext, resolver)
+
+		if !takesID(c) || c.RunE == nil {
+			continue
+		}
+		c.RunE = resolveIDRunE(cmdContext, resolver, c.RunE)
+	}
+}
+
+// resolveIDRunE wraps runE to resolve the first argument to an id before running it
+func resolveIDRunE[T any](cmdContext *CmdContext, resolver *IDResolver[T], runE func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return runE(cmd, args)
+		}
+
+		id, err := resolver.ResolveArg(cmd, cmdContext, args[0])
+		if err != nil {
+			return err
+		}
+		return runE(cmd, withFirstArg(args, id))
+	}
+}
+
+// isIDShaped checks if an argument can be an id, ids of resources consist of
+// letters and digits only, e.g. 'xkazYeJ0'
+func isIDShaped(arg string) bool {
+	return idRe.MatchString(arg)
+}
+
+// trimResolvePrefix returns a name from an argument with a name prefix
+func trimResolvePrefix(arg string) (string, bool) {
+	for _, prefix := range resolvePrefixes {
+		if name, ok := strings.CutPrefix(arg, prefix); ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// withFirstArg returns a copy of args with the first one replaced
+func withFirstArg(args []string, arg string) []string {
+	return append([]string{arg}, args[1:]...)
+}
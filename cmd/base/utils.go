@@ -12,6 +12,7 @@ import (
 	"time"
 
 	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/cache"
 	"github.com/serverscom/srvctl/internal/client"
 	"github.com/serverscom/srvctl/internal/config"
 	"github.com/serverscom/srvctl/internal/output"
@@ -64,13 +65,30 @@ func (c *CmdContext) GetClient() *client.Client {
 	return &client.Client{}
 }
 
-// SetupContext returns context with timeout based on 'http-timeout' from config or cli flag
+// SetupContext returns context of API requests of the command. 'http-timeout'
+// limits every attempt of a request, see SetupRetries, so the overall deadline
+// leaves room for all attempts and the longest waits between them.
 func SetupContext(cmd *cobra.Command, manager *config.Manager) (context.Context, context.CancelFunc) {
 	httpTimeout, err := manager.GetResolvedIntValue(cmd, "http-timeout")
 	if err != nil {
 		log.Fatal(err)
 	}
-	return context.WithTimeout(context.Background(), time.Duration(httpTimeout)*time.Second)
+	retries, err := manager.GetResolvedIntValue(cmd, "retries")
+	if err != nil {
+		log.Fatal(err)
+	}
+	maxWait, err := manager.GetResolvedIntValue(cmd, "retry-max-wait")
+	if err != nil {
+		log.Fatal(err)
+	}
+	retries = max(retries, 0)
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timeout := time.Duration(httpTimeout*(retries+1)+maxWait*retries) * time.Second
+	return context.WithTimeout(ctx, timeout)
 }
 
 // SetupProxy setup proxy envs for client based on 'proxy' defined in config or cli flag
@@ -175,6 +193,29 @@ func setupConfigManager(configPath string, context string) (*config.Manager, err
 	return m, nil
 }
 
+// NewCache returns catalog cache of the current context, nil if config is not stored in a file
+func NewCache(cmd *cobra.Command, cmdContext *CmdContext) (*cache.Cache, error) {
+	manager := cmdContext.GetManager()
+
+	contextName, err := cmd.Flags().GetString("context")
+	if err != nil {
+		return nil, err
+	}
+	if contextName == "" {
+		contextName = manager.GetDefaultContextName()
+	}
+	dir := manager.GetCacheDir(contextName)
+	if dir == "" {
+		return nil, nil
+	}
+
+	ttl, err := manager.GetResolvedIntValue(cmd, "cache-ttl")
+	if err != nil {
+		return nil, err
+	}
+	return cache.New(dir, time.Duration(ttl)*time.Hour), nil
+}
+
 func UsageRun(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
 
 func NoArgs(cmd *cobra.Command, args []string) error {
@@ -185,14 +226,35 @@ func NoArgs(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func fetchItems[T any](ctx context.Context, collection serverscom.Collection[T], opts []ListOptions[T]) ([]T, error) {
+// fetchItems fetches a page of a collection made by newCollection or all pages if requested,
+// with parallel > 1 all pages are fetched concurrently
+func fetchItems[T any](ctx context.Context, newCollection func() serverscom.Collection[T], opts []ListOptions[T], parallel int) ([]T, error) {
+	if !allPages(opts) {
+		return newCollection().List(ctx)
+	}
+	if parallel <= 1 {
+		return newCollection().Collect(ctx)
+	}
+
+	var items []T
+	err := collectPages(ctx, newCollection, parallel, func(page []T) error {
+		items = append(items, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// allPages reports whether all pages of a collection are requested
+func allPages[T any](opts []ListOptions[T]) bool {
 	for _, opt := range opts {
 		if baseOpts, ok := opt.(AllPager); ok && baseOpts.AllPages() {
-			return collection.Collect(ctx)
+			return true
 		}
 	}
-
-	return collection.List(ctx)
+	return false
 }
 
 func ValidateFlags(cmd *cobra.Command, required []string) error {
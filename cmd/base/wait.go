@@ -0,0 +1,88 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// WaitOptions holds flags for commands which can wait until a long-running
+// operation is completed
+type WaitOptions struct {
+	Wait         bool
+	Timeout      time.Duration
+	PollInterval time.Duration
+	// Backoff multiplies poll interval after each check, values <= 1 disable backoff
+	Backoff         float64
+	MaxPollInterval time.Duration
+}
+
+// AddFlags adds wait flags to the command
+func (o *WaitOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.BoolVar(&o.Wait, "wait", false, "Wait until the operation is completed")
+	flags.DurationVar(&o.Timeout, "wait-timeout", 30*time.Minute, "Maximum time to wait for the operation to complete")
+	flags.DurationVar(&o.PollInterval, "poll-interval", 10*time.Second, "Interval between status checks while waiting")
+}
+
+// AddBackoffFlags adds flags to configure growing interval between status checks
+func (o *WaitOptions) AddBackoffFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.Float64Var(&o.Backoff, "backoff", 1.5, "Multiplier applied to poll interval after each status check, 1 disables backoff")
+	flags.DurationVar(&o.MaxPollInterval, "max-poll-interval", time.Minute, "Maximum interval between status checks when backoff is used")
+}
+
+// nextInterval returns poll interval which follows the given one
+func (o *WaitOptions) nextInterval(interval time.Duration) time.Duration {
+	if o.Backoff <= 1 {
+		return interval
+	}
+	next := time.Duration(float64(interval) * o.Backoff)
+	if o.MaxPollInterval > 0 && next > o.MaxPollInterval {
+		next = o.MaxPollInterval
+	}
+	return next
+}
+
+// PollFunc checks the state of a resource once. It returns true when waiting
+// is over and an error if the resource has reached a failed state.
+type PollFunc func(ctx context.Context) (bool, error)
+
+// Poll calls fn every poll interval until it reports that waiting is over,
+// returns an error or the wait timeout expires. If backoff is set the interval
+// grows after each call up to the max poll interval.
+func Poll(ctx context.Context, opts *WaitOptions, fn PollFunc) error {
+	if opts.PollInterval <= 0 {
+		return fmt.Errorf("poll interval must be positive")
+	}
+	if opts.Timeout <= 0 {
+		return fmt.Errorf("wait timeout must be positive")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	interval := opts.PollInterval
+
+	for {
+		done, err := fn(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("timed out after %s: %w", opts.Timeout, err)
+			}
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s", opts.Timeout)
+		case <-time.After(interval):
+		}
+		interval = opts.nextInterval(interval)
+	}
+}
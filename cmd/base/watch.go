@@ -0,0 +1,248 @@
+package base
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"time"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/output"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// clearScreen moves the cursor home and clears a terminal
+const clearScreen = "\033[H\033[2J"
+
+// recordKeys are fields identifying records in watch events, the first present one is used
+var recordKeys = []string{"id", "fingerprint", "name"}
+
+// WatchOptions re-runs a command every interval until it's interrupted
+type WatchOptions struct {
+	watch    bool
+	interval time.Duration
+}
+
+// AddFlags adds watch flags to the command
+func (o *WatchOptions) AddFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.BoolVarP(&o.watch, "watch", "w", false, "Watch for changes: re-render the output on a terminal, print changed records as JSON events otherwise")
+	flags.DurationVar(&o.interval, "interval", 5*time.Second, "Interval between requests in watch mode")
+}
+
+// Wrap makes runE of cmd repeat in watch mode. Values passed to the formatter
+// by runE are captured and rendered by the watch loop.
+func (o *WatchOptions) Wrap(cmd *cobra.Command, cmdContext *CmdContext) {
+	o.AddFlags(cmd)
+
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !o.watch {
+			return runE(cmd, args)
+		}
+		if o.interval <= 0 {
+			return fmt.Errorf("interval must be positive")
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		return o.run(ctx, cmd, cmdContext, func() (any, error) {
+			formatter := cmdContext.GetOrCreateFormatter(cmd)
+
+			var value any
+			formatter.SetCapture(func(v any) { value = v })
+			defer formatter.SetCapture(nil)
+
+			if err := runE(cmd, args); err != nil {
+				return nil, err
+			}
+			return value, nil
+		})
+	}
+}
+
+// SetGetWatch adds watch flags to all 'get' subcommands of cmd
+func SetGetWatch(cmd *cobra.Command, cmdContext *CmdContext) {
+	for _, c := range cmd.Commands() {
+		SetGetWatch(c, cmdContext)
+
+		if c.Name() != "get" || c.RunE == nil || c.Flags().Lookup("watch") != nil {
+			continue
+		}
+		(&WatchOptions{}).Wrap(c, cmdContext)
+	}
+}
+
+// run fetches a value every interval and renders it until ctx is done.
+// On a terminal the output is re-rendered in place, otherwise or with json and ndjson
+// outputs only changed records are printed as newline-delimited JSON events.
+func (o *WatchOptions) run(ctx context.Context, cmd *cobra.Command, cmdContext *CmdContext, fetch func() (any, error)) error {
+	formatter := cmdContext.GetOrCreateFormatter(cmd)
+	w := cmd.OutOrStdout()
+	inPlace := isTerminal(w) && !slices.Contains([]string{"json", "ndjson"}, formatter.GetOutput())
+
+	var (
+		previous []watchRecord
+		started  bool
+	)
+	for {
+		value, err := fetch()
+
+		var notFound *serverscom.NotFoundError
+		switch {
+		case errors.As(err, &notFound) && started:
+			// the resource is deleted, there is nothing to watch anymore
+			if inPlace {
+				return err
+			}
+			return emitEvents(formatter, diffRecords(previous, nil))
+		case err != nil:
+			return err
+		}
+
+		if inPlace {
+			if err := o.render(w, cmd, formatter, value); err != nil {
+				return err
+			}
+		} else {
+			current, err := toRecords(value)
+			if err != nil {
+				return err
+			}
+			if err := emitEvents(formatter, diffRecords(previous, current)); err != nil {
+				return err
+			}
+			previous = current
+		}
+		started = true
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(o.interval):
+		}
+	}
+}
+
+// render clears the terminal and prints the value with a header like watch(1) does
+func (o *WatchOptions) render(w io.Writer, cmd *cobra.Command, formatter *output.Formatter, value any) error {
+	header := fmt.Sprintf("Every %s: %s", o.interval, cmd.CommandPath())
+	if _, err := fmt.Fprintf(w, "%s%s    %s\n\n", clearScreen, header, time.Now().Format(time.RFC1123)); err != nil {
+		return err
+	}
+	if value == nil {
+		return nil
+	}
+	return formatter.Format(value)
+}
+
+// watchRecord is a single record of watched output
+type watchRecord struct {
+	key    string
+	data   string
+	object any
+}
+
+// toRecords splits a value into records, a slice gives a record per item
+func toRecords(value any) ([]watchRecord, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// numbers are kept as is, ids may not fit into float64
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	items, ok := generic.([]any)
+	if !ok {
+		items = []any{generic}
+	}
+
+	records := make([]watchRecord, 0, len(items))
+	for i, item := range items {
+		itemData, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, watchRecord{
+			key:    recordKey(item, i),
+			data:   string(itemData),
+			object: item,
+		})
+	}
+	return records, nil
+}
+
+// recordKey returns an identifying field of a record or its position if there are none
+func recordKey(item any, position int) string {
+	if fields, ok := item.(map[string]any); ok {
+		for _, k := range recordKeys {
+			if v, ok := fields[k]; ok && v != nil {
+				return fmt.Sprint(v)
+			}
+		}
+	}
+	return fmt.Sprintf("#%d", position)
+}
+
+// diffRecords returns events for records added, modified or deleted between two states
+func diffRecords(previous, current []watchRecord) []output.WatchEvent {
+	previousByKey := make(map[string]watchRecord, len(previous))
+	for _, r := range previous {
+		previousByKey[r.key] = r
+	}
+	currentKeys := make(map[string]bool, len(current))
+
+	var events []output.WatchEvent
+	for _, r := range current {
+		currentKeys[r.key] = true
+		old, ok := previousByKey[r.key]
+		switch {
+		case !ok:
+			events = append(events, output.WatchEvent{Type: output.WatchEventAdded, Object: r.object})
+		case old.data != r.data:
+			events = append(events, output.WatchEvent{Type: output.WatchEventModified, Object: r.object})
+		}
+	}
+	for _, r := range previous {
+		if !currentKeys[r.key] {
+			events = append(events, output.WatchEvent{Type: output.WatchEventDeleted, Object: r.object})
+		}
+	}
+	return events
+}
+
+// emitEvents prints events as newline-delimited JSON
+func emitEvents(formatter *output.Formatter, events []output.WatchEvent) error {
+	for _, event := range events {
+		if err := formatter.FormatWatchEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isTerminal checks if output is written to a terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"errors"
+
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/internal/cache"
+	"github.com/spf13/cobra"
+)
+
+// errNoCache is returned when there is no config file to store cache next to
+var errNoCache = errors.New("cache is not available without a config file")
+
+func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage catalog cache",
+		Long:  `Manage on-disk cache of catalog responses (locations, server models, OS options etc.) stored per context next to the config file`,
+		PersistentPreRunE: base.CombinePreRunE(
+			base.CheckEmptyContexts(cmdContext),
+		),
+		Args: base.NoArgs,
+		Run:  base.UsageRun,
+	}
+
+	cmd.AddCommand(
+		newInfoCmd(cmdContext),
+		newClearCmd(cmdContext),
+	)
+
+	return cmd
+}
+
+// getCache returns cache of the current context
+func getCache(cmd *cobra.Command, cmdContext *base.CmdContext) (*cache.Cache, error) {
+	c, err := base.NewCache(cmd, cmdContext)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, errNoCache
+	}
+	return c, nil
+}
@@ -0,0 +1,24 @@
+package cache
+
+import (
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/spf13/cobra"
+)
+
+func newClearCmd(cmdContext *base.CmdContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Clear cache",
+		Long:  "Remove all cached responses of the current context",
+		Args:  base.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := getCache(cmd, cmdContext)
+			if err != nil {
+				return err
+			}
+			return c.Clear()
+		},
+	}
+
+	return cmd
+}
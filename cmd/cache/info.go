@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/spf13/cobra"
+)
+
+func newInfoCmd(cmdContext *base.CmdContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show cache info",
+		Long:  "Show cache directory, TTL and number of cached entries for the current context",
+		PersistentPreRunE: base.CombinePreRunE(
+			base.CheckOutputs(cmdContext, "text", "json", "yaml"),
+		),
+		Args: base.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := getCache(cmd, cmdContext)
+			if err != nil {
+				return err
+			}
+
+			info, err := c.Info()
+			if err != nil {
+				return err
+			}
+
+			formatter := cmdContext.GetOrCreateFormatter(cmd)
+			return formatter.FormatCacheInfo(info)
+		},
+	}
+
+	return cmd
+}
@@ -6,7 +6,7 @@ import (
 )
 
 var (
-	KnownConfigFlags = []string{"proxy", "http-timeout", "verbose", "output"}
+	KnownConfigFlags = []string{"proxy", "http-timeout", "verbose", "output", "confirm", "cache-ttl", "retries", "retry-max-wait", "retry-non-idempotent", "parallel"}
 )
 
 func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
@@ -35,6 +35,9 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 
 	cmd.AddCommand(
 		newFinalCmd(cmdContext),
+		newExplainCmd(cmdContext),
+		newEncryptCmd(cmdContext),
+		newDecryptCmd(cmdContext),
 		globalCmd,
 		contextCmd,
 	)
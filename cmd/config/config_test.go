@@ -1,12 +1,16 @@
 package config
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/serverscom/srvctl/cmd/testutils"
 	"github.com/serverscom/srvctl/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -204,3 +208,224 @@ func TestConfigContextUpdateCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigEncryptCmd(t *testing.T) {
+	testCases := []struct {
+		name           string
+		args           []string
+		expectedMode   string
+		expectedOutput string
+		plainSubstring string
+	}{
+		{
+			name:           "encrypt config",
+			expectedMode:   config.EncryptionModeConfig,
+			expectedOutput: "Config encrypted\n",
+		},
+		{
+			name:           "encrypt tokens only",
+			args:           []string{"--tokens-only"},
+			expectedMode:   config.EncryptionModeTokens,
+			expectedOutput: "Config tokens encrypted\n",
+			plainSubstring: "endpoint: https://test.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			data, err := yaml.Marshal(newTestConfig())
+			g.Expect(err).To(BeNil())
+			g.Expect(os.WriteFile(configPath, data, 0600)).To(Succeed())
+
+			t.Setenv(config.PassphraseEnv, "passphrase")
+
+			output, err := executeWithConfigFile(configPath, append([]string{"config", "encrypt"}, tc.args...))
+			g.Expect(err).To(BeNil())
+			g.Expect(output).To(Equal(tc.expectedOutput))
+
+			data, err = os.ReadFile(configPath)
+			g.Expect(err).To(BeNil())
+			g.Expect(string(data)).NotTo(ContainSubstring("secret"))
+			g.Expect(string(data)).To(ContainSubstring("mode: " + tc.expectedMode))
+			if tc.plainSubstring != "" {
+				g.Expect(string(data)).To(ContainSubstring(tc.plainSubstring))
+			} else {
+				g.Expect(string(data)).NotTo(ContainSubstring("test.com"))
+			}
+
+			manager, err := config.NewManager(configPath)
+			g.Expect(err).To(BeNil())
+			g.Expect(manager.GetEncryptionMode()).To(Equal(tc.expectedMode))
+			token, err := manager.GetToken("test")
+			g.Expect(err).To(BeNil())
+			g.Expect(token).To(Equal("secret"))
+
+			_, err = executeWithConfigFile(configPath, []string{"config", "encrypt"})
+			g.Expect(err).To(MatchError("config is already encrypted, decrypt it first"))
+
+			t.Setenv(config.PassphraseEnv, "wrong")
+			_, err = config.NewManager(configPath)
+			g.Expect(err).To(MatchError(ContainSubstring("wrong passphrase or corrupted config")))
+
+			t.Setenv(config.PassphraseEnv, "passphrase")
+			output, err = executeWithConfigFile(configPath, []string{"config", "decrypt"})
+			g.Expect(err).To(BeNil())
+			g.Expect(output).To(Equal("Config decrypted\n"))
+
+			data, err = os.ReadFile(configPath)
+			g.Expect(err).To(BeNil())
+			g.Expect(string(data)).To(ContainSubstring("token: secret"))
+			g.Expect(string(data)).NotTo(ContainSubstring("encryption"))
+
+			_, err = executeWithConfigFile(configPath, []string{"config", "decrypt"})
+			g.Expect(err).To(MatchError("config is not encrypted"))
+		})
+	}
+}
+
+func TestConfigSavePermissions(t *testing.T) {
+	g := NewWithT(t)
+
+	// configs created before tokens were protected may be readable by others
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	data, err := yaml.Marshal(newTestConfig())
+	g.Expect(err).To(BeNil())
+	g.Expect(os.WriteFile(configPath, data, 0644)).To(Succeed())
+	g.Expect(os.Chmod(configPath, 0644)).To(Succeed())
+
+	_, err = executeWithConfigFile(configPath, []string{"config", "global", "update", "--http-timeout", "100"})
+	g.Expect(err).To(BeNil())
+
+	info, err := os.Stat(configPath)
+	g.Expect(err).To(BeNil())
+	g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+	manager, err := config.NewManager(configPath)
+	g.Expect(err).To(BeNil())
+	g.Expect(manager.GetGlobalConfig()).To(HaveKeyWithValue("http-timeout", 100))
+}
+
+// executeWithConfigFile runs the config command with a manager loaded from the config file
+func executeWithConfigFile(configPath string, args []string) (string, error) {
+	manager, err := config.NewManager(configPath)
+	if err != nil {
+		return "", err
+	}
+
+	builder := testutils.NewTestCommandBuilder().
+		WithCommand(NewCmd(base.NewCmdContext(manager, nil))).
+		WithArgs(args)
+
+	err = builder.Build().Execute()
+	return builder.GetOutput(), err
+}
+
+func TestConfigExplainCmd(t *testing.T) {
+	testConfig := newTestConfig()
+	testConfig.GlobalConfig = config.ConfigOptions{
+		"http-timeout": 60,
+		"output":       "text",
+	}
+	testConfig.Contexts = append(testConfig.Contexts, config.Context{
+		Name:     "prod",
+		Endpoint: "https://prod.com",
+		Config: config.ConfigOptions{
+			"output": "json",
+		},
+	})
+
+	testCases := []struct {
+		name           string
+		args           []string
+		env            map[string]string
+		initContext    bool
+		expectedOutput []byte
+		expectedError  string
+	}{
+		{
+			name:           "value of default context",
+			args:           []string{"http-timeout"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "explain.txt")),
+		},
+		{
+			name:           "value of flag",
+			args:           []string{"http-timeout", "--http-timeout", "100"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "explain_flag.txt")),
+		},
+		{
+			name:           "value of env",
+			args:           []string{"http-timeout", "--context", "prod"},
+			env:            map[string]string{"SRVCTL_HTTP_TIMEOUT": "50"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "explain_env.json")),
+		},
+		{
+			name:           "value of env applied to flags",
+			args:           []string{"http-timeout", "--context", "prod"},
+			env:            map[string]string{"SRVCTL_HTTP_TIMEOUT": "50"},
+			initContext:    true,
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "explain_env.json")),
+		},
+		{
+			name:           "value of selected context",
+			args:           []string{"output", "--context", "prod"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "explain_context.json")),
+		},
+		{
+			name:          "invalid env",
+			args:          []string{"http-timeout"},
+			env:           map[string]string{"SRVCTL_HTTP_TIMEOUT": "soon"},
+			expectedError: `invalid value of SRVCTL_HTTP_TIMEOUT: strconv.Atoi: parsing "soon": invalid syntax`,
+		},
+		{
+			name:          "unknown key",
+			args:          []string{"token"},
+			expectedError: `unknown config key "token", allowed values: proxy, http-timeout, verbose, output, confirm, cache-ttl, retries, retry-max-wait, retry-non-idempotent, parallel`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			testCmdContext := testutils.NewTestCmdContext(nil)
+			testCmdContext.SetManagerConfig(&testConfig)
+
+			args := append([]string{"config", "explain"}, tc.args...)
+			if tc.initContext {
+				// the config is loaded and env vars are applied to flags as in srvctl
+				configPath := filepath.Join(t.TempDir(), "config.yaml")
+				data, err := yaml.Marshal(testConfig)
+				g.Expect(err).To(BeNil())
+				g.Expect(os.WriteFile(configPath, data, 0600)).To(Succeed())
+				args = append(args, "--config", configPath)
+			}
+
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(NewCmd(testCmdContext)).
+				WithArgs(args)
+			cmd := builder.Build()
+			if tc.initContext {
+				// as in srvctl, the root hook runs before the one of config commands
+				cobra.EnableTraverseRunHooks = true
+				defer func() { cobra.EnableTraverseRunHooks = false }()
+				cmd.PersistentPreRunE = base.InitCmdContext(testCmdContext)
+			}
+
+			err := cmd.Execute()
+
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).To(BeNil())
+				g.Expect(builder.GetOutput()).To(Equal(string(tc.expectedOutput)))
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/spf13/cobra"
+)
+
+func newDecryptCmd(cmdContext *base.CmdContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decrypt",
+		Short: "Decrypt the config file",
+		Long:  `Decrypt the config file encrypted by 'srvctl config encrypt' and store it in plain text`,
+		Args:  base.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager := cmdContext.GetManager()
+
+			if manager.GetEncryptionMode() == "" {
+				return errors.New("config is not encrypted")
+			}
+
+			manager.Decrypt()
+			if err := manager.Save(); err != nil {
+				return err
+			}
+
+			cmd.Println("Config decrypted")
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func newEncryptCmd(cmdContext *base.CmdContext) *cobra.Command {
+	var tokensOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt the config file",
+		Long: `Encrypt the config file, or only tokens of contexts, with a passphrase.
+The passphrase is taken from SRVCTL_PASSPHRASE or asked on a TTY, it's
+needed by every srvctl command afterwards.`,
+		Args: base.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager := cmdContext.GetManager()
+
+			if manager.GetEncryptionMode() != "" {
+				return errors.New("config is already encrypted, decrypt it first")
+			}
+
+			passphrase, err := config.ReadPassphrase(true)
+			if err != nil {
+				return err
+			}
+
+			mode := config.EncryptionModeConfig
+			if tokensOnly {
+				mode = config.EncryptionModeTokens
+			}
+			if err := manager.Encrypt(mode, passphrase); err != nil {
+				return err
+			}
+
+			if err := manager.Save(); err != nil {
+				return err
+			}
+
+			if tokensOnly {
+				cmd.Println("Config tokens encrypted")
+			} else {
+				cmd.Println("Config encrypted")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&tokensOnly, "tokens-only", false, "Encrypt only tokens of contexts, keep the rest of the config readable")
+
+	return cmd
+}
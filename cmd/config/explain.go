@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/internal/config"
+	"github.com/serverscom/srvctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newExplainCmd(cmdContext *base.CmdContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain <key>",
+		Short: "Show where a configuration value comes from",
+		Long: fmt.Sprintf(`Show the value of a configuration key and the layers setting it.
+Layers in order of precedence:
+- CLI-level arguments
+- Environment variables, %sHTTP_TIMEOUT for http-timeout
+- Context-level configurations of the selected context
+- Global configurations
+- Defaults`, config.EnvPrefix),
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: KnownConfigFlags,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager := cmdContext.GetManager()
+
+			key := args[0]
+			if !slices.Contains(KnownConfigFlags, key) {
+				return fmt.Errorf("unknown config key %q, allowed values: %s", key, strings.Join(KnownConfigFlags, ", "))
+			}
+
+			layers, err := manager.ResolveLayers(cmd, key)
+			if err != nil {
+				return err
+			}
+
+			explanation := output.ConfigExplanation{
+				Key:    key,
+				Value:  layers[0].Value,
+				Layer:  layers[0].Layer,
+				Source: layers[0].Source,
+				Layers: layers,
+			}
+
+			formatter := cmdContext.GetOrCreateFormatter(cmd)
+			return formatter.Format(explanation)
+		},
+	}
+
+	return cmd
+}
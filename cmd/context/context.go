@@ -18,6 +18,8 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newListCmd(cmdContext),
 		newUpdateCmd(cmdContext),
 		newDeleteCmd(cmdContext),
+		newExportCmd(cmdContext),
+		newImportCmd(cmdContext),
 	)
 
 	return cmd
@@ -1,12 +1,16 @@
 package context
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/serverscom/srvctl/cmd/testutils"
 	"github.com/serverscom/srvctl/internal/config"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -218,3 +222,234 @@ func TestContextDeleteCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestContextExportCmd(t *testing.T) {
+	testConfig := newTestConfig()
+	testConfig.Contexts[1].Config = config.ConfigOptions{"output": "json"}
+
+	testCases := []struct {
+		name           string
+		args           []string
+		expectedOutput []byte
+		expectError    bool
+	}{
+		{
+			name:           "export context",
+			args:           []string{"test"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "export.yaml")),
+		},
+		{
+			name:           "export context with token",
+			args:           []string{"test", "--with-token"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "export_with_token.yaml")),
+		},
+		{
+			name:           "export context in JSON",
+			args:           []string{"test", "-o", "json"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "export.json")),
+		},
+		{
+			name:        "export unknown context",
+			args:        []string{"unknown"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			testCmdContext := testutils.NewTestCmdContext(nil)
+			testCmdContext.SetManagerConfig(&testConfig)
+
+			contextCmd := NewCmd(testCmdContext)
+
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(contextCmd).
+				WithArgs(append([]string{"context", "export"}, tc.args...))
+
+			cmd := builder.Build()
+
+			err := cmd.Execute()
+
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(BeNil())
+				g.Expect(builder.GetOutput()).To(BeEquivalentTo(string(tc.expectedOutput)))
+			}
+		})
+	}
+}
+
+func TestContextImportCmd(t *testing.T) {
+	exportPath := filepath.Join(fixtureBasePath, "export.yaml")
+	exportWithTokenPath := filepath.Join(fixtureBasePath, "export_with_token.yaml")
+	tokenFile := filepath.Join(t.TempDir(), "ci")
+	configDir := t.TempDir()
+
+	testCases := []struct {
+		name             string
+		args             []string
+		input            string
+		configFile       bool
+		expectedContext  *config.Context
+		expectedToken    string
+		expectedDefault  string
+		expectedOutput   string
+		expectedErrorMsg string
+	}{
+		{
+			name: "import new context",
+			args: []string{"-f", exportWithTokenPath, "--rename", "ci", "--token-file", tokenFile},
+			expectedContext: &config.Context{
+				Name:      "ci",
+				Endpoint:  "https://test.com",
+				TokenFile: tokenFile,
+				Config:    config.ConfigOptions{"output": "json"},
+			},
+			expectedToken:   "secret",
+			expectedDefault: "default",
+			expectedOutput:  "Context \"ci\" imported\n",
+		},
+		{
+			name:       "import token to file next to config",
+			args:       []string{"-f", exportWithTokenPath, "--rename", "ci"},
+			configFile: true,
+			expectedContext: &config.Context{
+				Name:      "ci",
+				Endpoint:  "https://test.com",
+				TokenFile: filepath.Join(configDir, "tokens", "ci"),
+				Config:    config.ConfigOptions{"output": "json"},
+			},
+			expectedToken:   "secret",
+			expectedDefault: "default",
+			expectedOutput:  "Context \"ci\" imported\n",
+		},
+		{
+			name:             "import token without token file",
+			args:             []string{"-f", exportWithTokenPath, "--rename", "ci"},
+			expectedErrorMsg: "--token-file is required with --store=file when config isn't stored in a file",
+		},
+		{
+			name:             "import with invalid store",
+			args:             []string{"-f", exportWithTokenPath, "--rename", "ci", "--store", "command"},
+			expectedErrorMsg: `invalid store "command", allowed values: config, file`,
+		},
+		{
+			name:  "import from stdin as default",
+			args:  []string{"-f", "-", "--default", "--store", "config"},
+			input: `{"name": "ci", "endpoint": "https://ci.com", "token": "ci-secret"}`,
+			expectedContext: &config.Context{
+				Name:     "ci",
+				Endpoint: "https://ci.com",
+				Token:    "ci-secret",
+				Config:   config.ConfigOptions{},
+			},
+			expectedToken:   "ci-secret",
+			expectedDefault: "ci",
+			expectedOutput:  "Context \"ci\" imported\n",
+		},
+		{
+			name: "import without token",
+			args: []string{"-f", exportPath, "--rename", "ci"},
+			expectedContext: &config.Context{
+				Name:     "ci",
+				Endpoint: "https://test.com",
+				Config:   config.ConfigOptions{"output": "json"},
+			},
+			expectedDefault: "default",
+			expectedOutput:  "Context \"ci\" imported\nContext has no token, set it with 'srvctl login ci --force'\n",
+		},
+		{
+			name:             "import existing context",
+			args:             []string{"-f", exportPath},
+			expectedErrorMsg: `context "test" already exists. Use --rename to import it under another name or --force to override`,
+		},
+		{
+			name: "import existing context with force keeps token",
+			args: []string{"-f", exportPath, "--force"},
+			expectedContext: &config.Context{
+				Name:     "test",
+				Endpoint: "https://test.com",
+				Token:    "secret",
+				Config:   config.ConfigOptions{"output": "json"},
+			},
+			expectedToken:   "secret",
+			expectedDefault: "default",
+			expectedOutput:  "Context \"test\" imported\n",
+		},
+		{
+			name:             "import with invalid name",
+			args:             []string{"-f", exportPath, "--rename", "-ci"},
+			expectedErrorMsg: `invalid context name "-ci": must contain only letters, numbers, underscores, periods, and hyphens, and must start with a letter or number`,
+		},
+		{
+			name:             "import with invalid endpoint",
+			args:             []string{"-f", "-"},
+			input:            "name: ci\nendpoint: ftp://ci.com\n",
+			expectedErrorMsg: "invalid endpoint URL scheme, must be http or https",
+		},
+		{
+			name:             "import unknown fields",
+			args:             []string{"-f", "-"},
+			input:            "name: ci\nendpoint: https://ci.com\npassword: secret\n",
+			expectedErrorMsg: "could not parse context: yaml: unmarshal errors:\n  line 3: field password not found in type context.exportedContext",
+		},
+		{
+			name:             "import empty input",
+			args:             []string{"-f", "-"},
+			expectedErrorMsg: "no context to import",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			testConfig := newTestConfig()
+			testCmdContext := testutils.NewTestCmdContext(nil)
+			testCmdContext.SetManagerConfig(&testConfig)
+			if tc.configFile {
+				configPath := filepath.Join(configDir, "config.yaml")
+				data, err := yaml.Marshal(testConfig)
+				g.Expect(err).To(BeNil())
+				g.Expect(os.WriteFile(configPath, data, 0600)).To(Succeed())
+				manager, err := config.NewManager(configPath)
+				g.Expect(err).To(BeNil())
+				testCmdContext = base.NewCmdContext(manager, nil)
+			}
+
+			contextCmd := NewCmd(testCmdContext)
+
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(contextCmd).
+				WithInput(strings.NewReader(tc.input)).
+				WithArgs(append([]string{"context", "import"}, tc.args...))
+
+			cmd := builder.Build()
+
+			err := cmd.Execute()
+
+			if tc.expectedErrorMsg != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(Equal(tc.expectedErrorMsg))
+				return
+			}
+
+			g.Expect(err).To(BeNil())
+			g.Expect(builder.GetOutput()).To(Equal(tc.expectedOutput))
+
+			manager := testCmdContext.GetManager()
+			context, err := manager.GetContext(tc.expectedContext.Name)
+			g.Expect(err).To(BeNil())
+			g.Expect(*context).To(Equal(*tc.expectedContext))
+			g.Expect(manager.GetDefaultContextName()).To(Equal(tc.expectedDefault))
+			if tc.expectedToken != "" {
+				token, err := context.ResolveToken()
+				g.Expect(err).To(BeNil())
+				g.Expect(token).To(Equal(tc.expectedToken))
+			}
+		})
+	}
+}
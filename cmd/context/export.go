@@ -0,0 +1,60 @@
+package context
+
+import (
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// exportedContext is a context as it's written by export and read by import.
+// Token is omitted unless it's exported explicitly.
+type exportedContext struct {
+	Name     string               `yaml:"name" json:"name"`
+	Endpoint string               `yaml:"endpoint" json:"endpoint"`
+	Token    string               `yaml:"token,omitempty" json:"token,omitempty"`
+	Config   config.ConfigOptions `yaml:"config,omitempty" json:"config,omitempty"`
+}
+
+func newExportCmd(cmdContext *base.CmdContext) *cobra.Command {
+	var withToken bool
+
+	cmd := &cobra.Command{
+		Use:   "export <context-name>",
+		Short: "Export a context",
+		Long: `Export a context to move it to another machine or CI.
+The token is not exported unless --with-token is passed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager := cmdContext.GetManager()
+
+			ctx, err := manager.GetContext(args[0])
+			if err != nil {
+				return err
+			}
+
+			exported := exportedContext{
+				Name:     ctx.Name,
+				Endpoint: ctx.Endpoint,
+				Config:   ctx.Config,
+			}
+			if withToken {
+				// tokens stored outside the config are exported as plain tokens
+				if exported.Token, err = ctx.ResolveToken(); err != nil {
+					return err
+				}
+			}
+
+			formatter := cmdContext.GetOrCreateFormatter(cmd)
+			if formatter.IsStructured() {
+				return formatter.Format(exported)
+			}
+			// text output isn't importable, yaml is written instead
+			return yaml.NewEncoder(cmd.OutOrStdout()).Encode(exported)
+		},
+	}
+
+	cmd.Flags().BoolVar(&withToken, "with-token", false, "Include the API token in the export")
+
+	return cmd
+}
@@ -0,0 +1,159 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/internal/config"
+	"github.com/serverscom/srvctl/internal/validator"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// importTokenStores are token stores of the import command, there's no command printing an imported token
+var importTokenStores = []string{config.TokenStoreConfig, config.TokenStoreFile}
+
+func newImportCmd(cmdContext *base.CmdContext) *cobra.Command {
+	var (
+		path       string
+		rename     string
+		force      bool
+		setDefault bool
+		store      string
+		tokenFile  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import a context",
+		Long: `Import a context exported by 'srvctl context export'.
+Use '-f -' to read it from stdin.
+
+An exported token is stored in a file readable by the current user only, like
+'srvctl login' does. Use --store=config to keep it in the config,
+e.g. when the config is encrypted with 'srvctl config encrypt'.`,
+		Args: base.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.ValidateTokenStore(store, importTokenStores...); err != nil {
+				return err
+			}
+			manager := cmdContext.GetManager()
+
+			imported, err := readExportedContext(path, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			name := imported.Name
+			if rename != "" {
+				name = rename
+			}
+			if err := validator.ValidateContextName(name); err != nil {
+				return err
+			}
+			if err := validator.ValidateEndpoint(imported.Endpoint); err != nil {
+				return err
+			}
+
+			existingCtx, _ := manager.GetContext(name)
+			if existingCtx != nil && !force {
+				return fmt.Errorf("context %q already exists. Use --rename to import it under another name or --force to override", name)
+			}
+
+			if imported.Token != "" && store == config.TokenStoreFile && tokenFile == "" {
+				tokenFile = manager.GetTokenFilePath(name)
+				if tokenFile == "" {
+					return errors.New("--token-file is required with --store=file when config isn't stored in a file")
+				}
+			}
+
+			cfgCtx := config.Context{
+				Name:     name,
+				Endpoint: imported.Endpoint,
+				Config:   imported.Config,
+			}
+			if imported.Token != "" {
+				switch store {
+				case config.TokenStoreConfig:
+					cfgCtx.Token = imported.Token
+				case config.TokenStoreFile:
+					if err := config.WriteTokenFile(tokenFile, imported.Token); err != nil {
+						return fmt.Errorf("failed to save token: %w", err)
+					}
+					cfgCtx.TokenFile = tokenFile
+				}
+			}
+			// keep the token of the overridden context if the export has none
+			if imported.Token == "" && existingCtx != nil {
+				cfgCtx.Token = existingCtx.Token
+				cfgCtx.TokenFile = existingCtx.TokenFile
+				cfgCtx.TokenCommand = existingCtx.TokenCommand
+			}
+			if cfgCtx.Config == nil {
+				cfgCtx.Config = make(map[string]any)
+			}
+
+			if err := manager.SetContext(cfgCtx); err != nil {
+				return fmt.Errorf("failed to update context: %w", err)
+			}
+
+			if setDefault {
+				if err := manager.SetDefaultContext(name); err != nil {
+					return fmt.Errorf("failed to set default context: %w", err)
+				}
+			}
+
+			if err := manager.Save(); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+
+			cmd.Printf("Context %q imported\n", name)
+			if cfgCtx.Token == "" && cfgCtx.TokenFile == "" && cfgCtx.TokenCommand == "" {
+				cmd.Printf("Context has no token, set it with 'srvctl login %s --force'\n", name)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&path, "file", "f", "", "Path to the exported context, use '-' to read from stdin")
+	cmd.Flags().StringVar(&rename, "rename", "", "Import the context under another name")
+	cmd.Flags().BoolVar(&force, "force", false, "Override an existing context with the same name")
+	cmd.Flags().BoolVarP(&setDefault, "default", "d", false, "Set as default context")
+	cmd.Flags().StringVar(&store, "store", config.DefaultTokenStore, "Where to store an imported token (file/config)")
+	cmd.Flags().StringVar(&tokenFile, "token-file", "", "Token file path for --store=file, defaults to tokens/<context-name> next to the config")
+
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// readExportedContext reads an exported context from a file or from in if path is '-'.
+// Both yaml and json exports are accepted.
+func readExportedContext(path string, in io.Reader) (*exportedContext, error) {
+	r := in
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		defer file.Close() //nolint:errcheck
+		r = file
+	}
+
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	var imported exportedContext
+	if err := decoder.Decode(&imported); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("no context to import")
+		}
+		return nil, fmt.Errorf("could not parse context: %w", err)
+	}
+
+	return &imported, nil
+}
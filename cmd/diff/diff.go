@@ -0,0 +1,65 @@
+package diff
+
+import (
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/internal/manifest"
+	"github.com/spf13/cobra"
+)
+
+// changesExitCode is an exit code used when manifests differ from the current state
+const changesExitCode = 2
+
+func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
+	var (
+		files []string
+		prune bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff -f <path>",
+		Short: "Show changes apply would make",
+		Long:  `Compare resources described in manifest files with the current state without changing anything. Exits with code 2 if there are changes.`,
+		PersistentPreRunE: base.CombinePreRunE(
+			base.CheckOutputs(cmdContext, "text", "json", "yaml"),
+			base.CheckEmptyContexts(cmdContext),
+		),
+		Args: base.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resources, err := manifest.ReadFiles(files, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			manager := cmdContext.GetManager()
+			ctx, cancel := base.SetupContext(cmd, manager)
+			defer cancel()
+
+			base.SetupProxy(cmd, manager)
+			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
+
+			changes, err := manifest.Plan(ctx, scClient, resources, manifest.PlanOptions{Prune: prune})
+			if err != nil {
+				return err
+			}
+
+			formatter := cmdContext.GetOrCreateFormatter(cmd)
+			if err := formatter.FormatDiff(changes); err != nil {
+				return err
+			}
+
+			if manifest.HasChanges(changes) {
+				// the diff is already printed, exit code is the only signal
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+				return &base.ExitCodeError{Code: changesExitCode, Message: "there are changes"}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&files, "filename", "f", nil, "path to manifest file or directory, '-' to read from stdin")
+	cmd.Flags().BoolVar(&prune, "prune", false, "show deletion of resources which have a match label used in manifests but are not described in them")
+	_ = cmd.MarkFlagRequired("filename")
+
+	return cmd
+}
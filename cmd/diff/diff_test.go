@@ -0,0 +1,156 @@
+package diff
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/cmd/testutils"
+	"github.com/serverscom/srvctl/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	fixtureBasePath = filepath.Join("..", "..", "testdata", "manifests")
+	existingKeys    = []serverscom.SSHKey{
+		{
+			Name:        "existing-key",
+			Fingerprint: "existing-fingerprint",
+			Labels:      map[string]string{"env": "prod"},
+		},
+		{
+			Name:        "old-name",
+			Fingerprint: "labeled-fingerprint",
+			Labels:      map[string]string{"app": "deploy"},
+		},
+		{
+			Name:        "stale",
+			Fingerprint: "stale-fingerprint",
+			Labels:      map[string]string{"app": "stale"},
+		},
+	}
+)
+
+func TestDiffCmd(t *testing.T) {
+	testCases := []struct {
+		name             string
+		output           string
+		args             []string
+		configureMock    func(*mocks.MockCollection[serverscom.SSHKey])
+		expectedOutput   []byte
+		expectedExitCode int
+		expectError      bool
+	}{
+		{
+			name:             "diff yaml manifest with prune",
+			args:             []string{"-f", filepath.Join(fixtureBasePath, "ssh_keys.yaml"), "--prune"},
+			expectedOutput:   testutils.ReadFixture(filepath.Join(fixtureBasePath, "diff.txt")),
+			expectedExitCode: changesExitCode,
+			configureMock: func(collection *mocks.MockCollection[serverscom.SSHKey]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return(existingKeys, nil)
+			},
+		},
+		{
+			name:             "diff json manifest",
+			output:           "json",
+			args:             []string{"-f", filepath.Join(fixtureBasePath, "ssh_keys.json")},
+			expectedOutput:   testutils.ReadFixture(filepath.Join(fixtureBasePath, "diff.json")),
+			expectedExitCode: changesExitCode,
+			configureMock: func(collection *mocks.MockCollection[serverscom.SSHKey]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return(existingKeys, nil)
+			},
+		},
+		{
+			name:           "diff without changes",
+			args:           []string{"-f", filepath.Join(fixtureBasePath, "ssh_keys_unchanged.yaml"), "--prune"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "diff_unchanged.txt")),
+			configureMock: func(collection *mocks.MockCollection[serverscom.SSHKey]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return(existingKeys, nil)
+			},
+		},
+		{
+			name: "diff with list error",
+			args: []string{"-f", filepath.Join(fixtureBasePath, "ssh_keys.yaml")},
+			configureMock: func(collection *mocks.MockCollection[serverscom.SSHKey]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return(nil, errors.New("some error"))
+			},
+			expectError: true,
+		},
+		{
+			name:        "diff without filename",
+			expectError: true,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sshServiceHandler := mocks.NewMockSSHKeysService(mockCtrl)
+	collectionHandler := mocks.NewMockCollection[serverscom.SSHKey](mockCtrl)
+
+	sshServiceHandler.EXPECT().
+		Collection().
+		Return(collectionHandler).
+		AnyTimes()
+
+	scClient := serverscom.NewClientWithEndpoint("", "")
+	scClient.SSHKeys = sshServiceHandler
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			if tc.configureMock != nil {
+				tc.configureMock(collectionHandler)
+			}
+
+			testCmdContext := testutils.NewTestCmdContext(scClient)
+			diffCmd := NewCmd(testCmdContext)
+
+			args := []string{"diff"}
+			if len(tc.args) > 0 {
+				args = append(args, tc.args...)
+			}
+			if tc.output != "" {
+				args = append(args, "--output", tc.output)
+			}
+
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(diffCmd).
+				WithArgs(args)
+
+			cmd := builder.Build()
+
+			err := cmd.Execute()
+
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			if tc.expectedExitCode != 0 {
+				var exitErr *base.ExitCodeError
+				g.Expect(errors.As(err, &exitErr)).To(BeTrue())
+				g.Expect(exitErr.Code).To(Equal(tc.expectedExitCode))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+			if tc.output == "json" {
+				g.Expect(builder.GetOutput()).To(MatchJSON(tc.expectedOutput))
+			} else {
+				g.Expect(builder.GetOutput()).To(BeEquivalentTo(string(tc.expectedOutput)))
+			}
+		})
+	}
+}
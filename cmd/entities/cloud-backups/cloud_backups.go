@@ -36,6 +36,15 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newRestoreCmd(cmdContext),
 	)
 
+	base.SetIDCompletion(cmd, base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.CloudBlockStorageBackup], error) {
+			return client.CloudBlockStorageBackups.Collection(), nil
+		},
+		func(b serverscom.CloudBlockStorageBackup) cobra.Completion {
+			return cobra.CompletionWithDesc(b.ID, b.Name)
+		},
+	))
+
 	base.AddFormatFlags(cmd)
 
 	return cmd
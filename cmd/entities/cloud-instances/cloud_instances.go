@@ -46,6 +46,18 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newDeletePTRCmd(cmdContext),
 	)
 
+	collection := func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.CloudComputingInstance], error) {
+		return client.CloudComputingInstances.Collection(), nil
+	}
+	base.SetIDCompletion(cmd, base.CompleteIDs(cmdContext, collection,
+		func(i serverscom.CloudComputingInstance) cobra.Completion {
+			return cobra.CompletionWithDesc(i.ID, i.Name)
+		},
+	))
+	base.SetIDResolution(cmd, cmdContext, base.NewIDResolver("cloud instance", collection,
+		func(i serverscom.CloudComputingInstance) (string, string) { return i.ID, i.Name },
+	))
+
 	base.AddFormatFlags(cmd)
 
 	return cmd
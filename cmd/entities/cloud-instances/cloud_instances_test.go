@@ -1,14 +1,20 @@
 package cloudinstances
 
 import (
+	"bytes"
 	"errors"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	. "github.com/onsi/gomega"
 	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/serverscom/srvctl/cmd/testutils"
+	"github.com/serverscom/srvctl/internal/config"
 	"github.com/serverscom/srvctl/internal/mocks"
 	"go.uber.org/mock/gomock"
 )
@@ -17,7 +23,7 @@ var (
 	fixtureBasePath      = filepath.Join("..", "..", "..", "testdata", "entities", "cloud-instances")
 	skeletonTemplatePath = filepath.Join("..", "..", "..", "internal", "output", "skeletons", "templates", "cloud-instances")
 	fixedTime            = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
-	testCloudInstanceID  = "test-instance-id"
+	testCloudInstanceID  = "testInstanceId01"
 	testRegionCode       = "AMS1"
 	testCloudInstance    = serverscom.CloudComputingInstance{
 		ID:                 testCloudInstanceID,
@@ -43,7 +49,7 @@ var (
 		Updated:            fixedTime,
 	}
 	testCloudInstance2 = serverscom.CloudComputingInstance{
-		ID:                 "test-instance-id2",
+		ID:                 "testInstanceId02",
 		Name:               "test-instance2",
 		RegionID:           1,
 		RegionCode:         testRegionCode,
@@ -451,59 +457,167 @@ func TestUpdateCloudInstancesCmd(t *testing.T) {
 
 func TestDeleteCloudInstancesCmd(t *testing.T) {
 	testCases := []struct {
-		name        string
-		instanceID  string
-		expectError bool
+		name           string
+		instanceID     string
+		args           []string
+		input          func() io.Reader
+		config         *config.Config
+		env            map[string]string
+		configureMock  func(*mocks.MockCloudComputingInstancesService)
+		expectedPrompt string
+		expectedErr    error
+		expectError    bool
 	}{
 		{
 			name:       "delete cloud instance",
 			instanceID: testCloudInstanceID,
+			args:       []string{"--yes"},
+			configureMock: func(mock *mocks.MockCloudComputingInstancesService) {
+				mock.EXPECT().
+					Delete(gomock.Any(), testCloudInstanceID).
+					Return(nil)
+			},
+		},
+		{
+			name:       "delete cloud instance with error",
+			instanceID: testCloudInstanceID,
+			args:       []string{"--yes"},
+			configureMock: func(mock *mocks.MockCloudComputingInstancesService) {
+				mock.EXPECT().
+					Delete(gomock.Any(), testCloudInstanceID).
+					Return(errors.New("some error"))
+			},
+			expectError: true,
+		},
+		{
+			name:       "delete cloud instance with confirmation",
+			instanceID: testCloudInstanceID,
+			input:      func() io.Reader { return strings.NewReader("y\n") },
+			configureMock: func(mock *mocks.MockCloudComputingInstancesService) {
+				mock.EXPECT().
+					Get(gomock.Any(), testCloudInstanceID).
+					Return(&testCloudInstance, nil)
+				mock.EXPECT().
+					Delete(gomock.Any(), testCloudInstanceID).
+					Return(nil)
+			},
+			expectedPrompt: `Delete cloud instance "test-instance" (ID: testInstanceId01, location: AMS1)? [y/N]: `,
+		},
+		{
+			name:       "delete cloud instance declined",
+			instanceID: testCloudInstanceID,
+			input:      func() io.Reader { return strings.NewReader("n\n") },
+			configureMock: func(mock *mocks.MockCloudComputingInstancesService) {
+				mock.EXPECT().
+					Get(gomock.Any(), testCloudInstanceID).
+					Return(&testCloudInstance, nil)
+			},
+			expectedPrompt: `Delete cloud instance "test-instance" (ID: testInstanceId01, location: AMS1)? [y/N]: `,
+			expectedErr:    base.ErrAborted,
+		},
+		{
+			name:       "delete cloud instance without terminal",
+			instanceID: testCloudInstanceID,
+			input: func() io.Reader {
+				f, err := os.Open(os.DevNull)
+				if err != nil {
+					panic(err)
+				}
+				t.Cleanup(func() { _ = f.Close() })
+				return f
+			},
+			expectedErr: base.ErrConfirmationRequired,
+		},
+		{
+			name:       "delete cloud instance with confirmation disabled in config",
+			instanceID: testCloudInstanceID,
+			config: &config.Config{
+				DefaultContext: "test",
+				Contexts: []config.Context{
+					{Name: "test", Config: config.ConfigOptions{"confirm": false}},
+				},
+			},
+			configureMock: func(mock *mocks.MockCloudComputingInstancesService) {
+				mock.EXPECT().
+					Delete(gomock.Any(), testCloudInstanceID).
+					Return(nil)
+			},
 		},
 		{
-			name:        "delete cloud instance with error",
+			name:       "delete cloud instance with invalid confirm in config",
+			instanceID: testCloudInstanceID,
+			config: &config.Config{
+				DefaultContext: "test",
+				Contexts: []config.Context{
+					{Name: "test", Config: config.ConfigOptions{"confirm": "maybe"}},
+				},
+			},
+			input: func() io.Reader { return strings.NewReader("n\n") },
+			configureMock: func(mock *mocks.MockCloudComputingInstancesService) {
+				mock.EXPECT().
+					Get(gomock.Any(), testCloudInstanceID).
+					Return(&testCloudInstance, nil)
+			},
+			expectedErr: base.ErrAborted,
+		},
+		{
+			name:        "delete cloud instance with invalid confirm env",
 			instanceID:  testCloudInstanceID,
+			env:         map[string]string{"SRVCTL_CONFIRM": "maybe"},
+			input:       func() io.Reader { return strings.NewReader("y\n") },
 			expectError: true,
 		},
 	}
 
-	mockCtrl := gomock.NewController(t)
-	defer mockCtrl.Finish()
-
-	cloudServiceHandler := mocks.NewMockCloudComputingInstancesService(mockCtrl)
-
-	scClient := serverscom.NewClientWithEndpoint("", "")
-	scClient.CloudComputingInstances = cloudServiceHandler
-
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			var err error
-			if tc.expectError {
-				err = errors.New("some error")
+			for k, v := range tc.env {
+				t.Setenv(k, v)
 			}
-			cloudServiceHandler.EXPECT().
-				Delete(gomock.Any(), tc.instanceID).
-				Return(err)
+
+			mockCtrl := gomock.NewController(t)
+			cloudServiceHandler := mocks.NewMockCloudComputingInstancesService(mockCtrl)
+			if tc.configureMock != nil {
+				tc.configureMock(cloudServiceHandler)
+			}
+
+			scClient := serverscom.NewClientWithEndpoint("", "")
+			scClient.CloudComputingInstances = cloudServiceHandler
 
 			testCmdContext := testutils.NewTestCmdContext(scClient)
+			if tc.config != nil {
+				testCmdContext.SetManagerConfig(tc.config)
+			}
 			cloudCmd := NewCmd(testCmdContext)
 
-			args := []string{"cloud-instances", "delete", tc.instanceID}
+			args := append([]string{"cloud-instances", "delete", tc.instanceID}, tc.args...)
 
 			builder := testutils.NewTestCommandBuilder().
 				WithCommand(cloudCmd).
 				WithArgs(args)
+			if tc.input != nil {
+				builder = builder.WithInput(tc.input())
+			}
 
 			cmd := builder.Build()
+			var stderr bytes.Buffer
+			cmd.SetErr(&stderr)
 
-			err = cmd.Execute()
+			err := cmd.Execute()
 
-			if tc.expectError {
+			switch {
+			case tc.expectedErr != nil:
+				g.Expect(err).To(MatchError(tc.expectedErr))
+			case tc.expectError:
 				g.Expect(err).To(HaveOccurred())
-			} else {
+			default:
 				g.Expect(err).To(BeNil())
 			}
+			if tc.expectedPrompt != "" {
+				g.Expect(stderr.String()).To(HavePrefix(tc.expectedPrompt))
+			}
 		})
 	}
 }
@@ -1,16 +1,29 @@
 package cloudinstances
 
 import (
+	"context"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
 	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/spf13/cobra"
 )
 
 func newDeleteCmd(cmdContext *base.CmdContext) *cobra.Command {
+	confirmOpts := &base.ConfirmOptions{}
+
 	cmd := &cobra.Command{
 		Use:   "delete <instance-id>",
 		Short: "Delete cloud instance by instance ID",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceID := args[0]
+			getFn := func(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+				return client.CloudComputingInstances.Get(ctx, id)
+			}
+			if err := confirmOpts.ConfirmResource(cmd, cmdContext, "Delete", "cloud instance", instanceID, getFn); err != nil {
+				return err
+			}
+
 			manager := cmdContext.GetManager()
 			ctx, cancel := base.SetupContext(cmd, manager)
 			defer cancel()
@@ -19,10 +32,11 @@ func newDeleteCmd(cmdContext *base.CmdContext) *cobra.Command {
 
 			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
 
-			instanceID := args[0]
 			return scClient.CloudComputingInstances.Delete(ctx, instanceID)
 		},
 	}
 
+	confirmOpts.AddFlags(cmd)
+
 	return cmd
 }
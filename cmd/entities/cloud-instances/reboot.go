@@ -1,16 +1,29 @@
 package cloudinstances
 
 import (
+	"context"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
 	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/spf13/cobra"
 )
 
 func newRebootCmd(cmdContext *base.CmdContext) *cobra.Command {
+	bulkOpts := &base.BulkOptions{}
+
 	cmd := &cobra.Command{
 		Use:   "reboot <instance-id>",
 		Short: "Reboot cloud instance by instance ID",
-		Args:  cobra.ExactArgs(1),
+		Args:  bulkOpts.Args,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if bulkOpts.IsBulk() {
+				return bulkOpts.Run(cmd, cmdContext, "Reboot", selectInstanceIDs,
+					func(ctx context.Context, client *serverscom.Client, id string) error {
+						_, err := client.CloudComputingInstances.Reboot(ctx, id)
+						return err
+					})
+			}
+
 			manager := cmdContext.GetManager()
 			ctx, cancel := base.SetupContext(cmd, manager)
 			defer cancel()
@@ -33,5 +46,13 @@ func newRebootCmd(cmdContext *base.CmdContext) *cobra.Command {
 		},
 	}
 
+	bulkOpts.AddFlags(cmd)
+
 	return cmd
 }
+
+// selectInstanceIDs returns ids of cloud instances matching label selector
+func selectInstanceIDs(ctx context.Context, client *serverscom.Client, selector string) ([]string, error) {
+	return base.SelectIDs(ctx, client.CloudComputingInstances.Collection(), selector,
+		func(i serverscom.CloudComputingInstance) string { return i.ID })
+}
@@ -38,6 +38,13 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newDeleteSnapshotCmd(cmdContext),
 	)
 
+	base.SetIDCompletion(cmd, base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.CloudComputingRegion], error) {
+			return client.CloudComputingRegions.Collection(), nil
+		},
+		func(r serverscom.CloudComputingRegion) cobra.Completion { return base.Int64Completion(r.ID, r.Name) },
+	))
+
 	base.AddFormatFlags(cmd)
 
 	return cmd
@@ -37,6 +37,18 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newVolumeDetachCmd(cmdContext),
 	)
 
+	collection := func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.CloudBlockStorageVolume], error) {
+		return client.CloudBlockStorageVolumes.Collection(), nil
+	}
+	base.SetIDCompletion(cmd, base.CompleteIDs(cmdContext, collection,
+		func(v serverscom.CloudBlockStorageVolume) cobra.Completion {
+			return cobra.CompletionWithDesc(v.ID, v.Name)
+		},
+	))
+	base.SetIDResolution(cmd, cmdContext, base.NewIDResolver("volume", collection,
+		func(v serverscom.CloudBlockStorageVolume) (string, string) { return v.ID, v.Name },
+	))
+
 	base.AddFormatFlags(cmd)
 
 	return cmd
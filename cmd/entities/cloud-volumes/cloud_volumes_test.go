@@ -14,8 +14,8 @@ import (
 )
 
 var (
-	testVolumeID         = "vol-12345"
-	testInstanceID       = "instance-123"
+	testVolumeID         = "vol012345"
+	testInstanceID       = "instance123"
 	fixtureBasePath      = filepath.Join("..", "..", "..", "testdata", "entities", "cloud-volumes")
 	skeletonTemplatePath = filepath.Join("..", "..", "..", "internal", "output", "skeletons", "templates", "cloud-volumes")
 	fixedTime            = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
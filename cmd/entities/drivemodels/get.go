@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
 	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/spf13/cobra"
 )
@@ -50,5 +51,12 @@ func newGetCmd(cmdContext *base.CmdContext) *cobra.Command {
 	_ = cmd.MarkFlagRequired("location-id")
 	_ = cmd.MarkFlagRequired("server-model-id")
 
+	cmd.ValidArgsFunction = base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.DriveModel], error) {
+			return client.Locations.DriveModelOptions(locationID, serverModelID), nil
+		},
+		func(m serverscom.DriveModel) cobra.Completion { return base.Int64Completion(m.ID, m.Name) },
+	)
+
 	return cmd
 }
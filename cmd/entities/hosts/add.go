@@ -168,6 +168,7 @@ func (f *AddSBMFlags) FillInput(cmd *cobra.Command, input *serverscom.SBMServerC
 
 func newAddEBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 	flags := &AddEBMFlags{}
+	waitOpts := &base.WaitOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "add",
@@ -223,6 +224,13 @@ func newAddEBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 			}
 
 			if server != nil {
+				if waitOpts.Wait {
+					result, err := waitForHosts(cmd, &EBMGetMgr{}, cmdContext, waitOpts, server, hostReady)
+					if err != nil {
+						return err
+					}
+					return formatter.Format(result)
+				}
 				return formatter.Format(server)
 			}
 
@@ -250,11 +258,14 @@ func newAddEBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 	cmd.Flags().StringVar(&flags.UserData, "user-data", "", "Content of user data")
 	cmd.Flags().StringToStringVar(&flags.Labels, "labels", nil, "The set of labels which will be applied to the all hosts of this operation")
 
+	waitOpts.AddFlags(cmd)
+
 	return cmd
 }
 
 func newAddSBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 	flags := &AddSBMFlags{}
+	waitOpts := &base.WaitOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "add",
@@ -308,6 +319,13 @@ func newAddSBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 			}
 
 			if server != nil {
+				if waitOpts.Wait {
+					result, err := waitForHosts(cmd, &SBMGetMgr{}, cmdContext, waitOpts, server, hostReady)
+					if err != nil {
+						return err
+					}
+					return formatter.Format(result)
+				}
 				return formatter.Format(server)
 			}
 
@@ -325,5 +343,7 @@ func newAddSBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 	cmd.Flags().StringVar(&flags.UserDataFile, "user-data-file", "", "Path to user data which should be read")
 	cmd.Flags().StringVar(&flags.UserData, "user-data", "", "Content of user data")
 
+	waitOpts.AddFlags(cmd)
+
 	return cmd
 }
@@ -224,6 +224,112 @@ func TestAddEBMCmd(t *testing.T) {
 					Return([]serverscom.DedicatedServer{testDS}, nil)
 			},
 		},
+		{
+			name:           "create ebm server and wait",
+			output:         "json",
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "create_ebm_wait_resp.json")),
+			args: []string{
+				"--input", filepath.Join(fixtureBasePath, "create_ebm_input.json"),
+				"--wait",
+				"--poll-interval", "1ms",
+			},
+			configureMock: func(mock *mocks.MockHostsService) {
+				provisioning := testDS
+				provisioning.Status = "init"
+				provisioning.OperationalStatus = "provisioning"
+				ready := testDS
+				ready.OperationalStatus = "normal"
+				ready.PowerStatus = "powered_on"
+
+				mock.EXPECT().
+					CreateDedicatedServers(gomock.Any(), expectedInput).
+					Return([]serverscom.DedicatedServer{provisioning}, nil)
+				gomock.InOrder(
+					mock.EXPECT().
+						GetDedicatedServer(gomock.Any(), testId).
+						Return(&provisioning, nil),
+					mock.EXPECT().
+						GetDedicatedServer(gomock.Any(), testId).
+						Return(&ready, nil),
+				)
+			},
+		},
+		{
+			name:           "create ebm servers and wait for all of them",
+			output:         "json",
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "create_ebm_wait_multiple_resp.json")),
+			args: []string{
+				"--input", filepath.Join(fixtureBasePath, "create_ebm_input.json"),
+				"--wait",
+				"--poll-interval", "1ms",
+			},
+			configureMock: func(mock *mocks.MockHostsService) {
+				provisioning := testDS
+				provisioning.Status = "init"
+				provisioning.OperationalStatus = "provisioning"
+				ready := testDS
+				ready.OperationalStatus = "normal"
+				ready.PowerStatus = "powered_on"
+				other := testDS
+				other.ID = "otherId"
+				otherReady := ready
+				otherReady.ID = "otherId"
+
+				mock.EXPECT().
+					CreateDedicatedServers(gomock.Any(), expectedInput).
+					Return([]serverscom.DedicatedServer{provisioning, other}, nil)
+				gomock.InOrder(
+					mock.EXPECT().
+						GetDedicatedServer(gomock.Any(), testId).
+						Return(&provisioning, nil),
+					mock.EXPECT().
+						GetDedicatedServer(gomock.Any(), "otherId").
+						Return(&otherReady, nil),
+					mock.EXPECT().
+						GetDedicatedServer(gomock.Any(), testId).
+						Return(&ready, nil),
+				)
+			},
+		},
+		{
+			name: "create ebm server and wait with failed state",
+			args: []string{
+				"--input", filepath.Join(fixtureBasePath, "create_ebm_input.json"),
+				"--wait",
+				"--poll-interval", "1ms",
+			},
+			configureMock: func(mock *mocks.MockHostsService) {
+				failed := testDS
+				failed.Status = "failed"
+
+				mock.EXPECT().
+					CreateDedicatedServers(gomock.Any(), expectedInput).
+					Return([]serverscom.DedicatedServer{testDS}, nil)
+				mock.EXPECT().
+					GetDedicatedServer(gomock.Any(), testId).
+					Return(&failed, nil)
+			},
+			expectError: true,
+		},
+		{
+			name: "create ebm server and wait with timeout",
+			args: []string{
+				"--input", filepath.Join(fixtureBasePath, "create_ebm_input.json"),
+				"--wait",
+				"--wait-timeout", "5ms",
+				"--poll-interval", "1ms",
+			},
+			configureMock: func(mock *mocks.MockHostsService) {
+				mock.EXPECT().
+					CreateDedicatedServers(gomock.Any(), expectedInput).
+					Return([]serverscom.DedicatedServer{testDS}, nil)
+				mock.EXPECT().
+					GetDedicatedServer(gomock.Any(), testId).
+					Return(&testDS, nil).
+					MinTimes(1)
+			},
+			expectError: true,
+		},
 		{
 			name:        "create ebm server with error",
 			expectError: true,
@@ -641,7 +747,7 @@ func TestScheduleReleaseEBMCmd(t *testing.T) {
 			testCmdContext := testutils.NewTestCmdContext(scClient)
 			ebmCmd := NewEBMCmd(testCmdContext)
 
-			args := []string{"ebm", "schedule-release", tc.id}
+			args := []string{"ebm", "schedule-release", tc.id, "--yes"}
 			if len(tc.args) > 0 {
 				args = append(args, tc.args...)
 			}
@@ -85,6 +85,16 @@ func newHostTypeCmd(cmdContext *base.CmdContext, hostTypeCmd HostTypeCmd) *cobra
 		hostCmd.AddCommand(cmdFunc(cmdContext))
 	}
 
+	collection := func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.Host], error) {
+		return client.Hosts.Collection().SetParam("type", hostTypeCmd.typeFlag), nil
+	}
+	base.SetIDCompletion(hostCmd, base.CompleteIDs(cmdContext, collection,
+		func(h serverscom.Host) cobra.Completion { return cobra.CompletionWithDesc(h.ID, h.Title) },
+	))
+	base.SetIDResolution(hostCmd, cmdContext, base.NewIDResolver("host", collection,
+		func(h serverscom.Host) (string, string) { return h.ID, h.Title },
+	))
+
 	base.AddFormatFlags(hostCmd)
 
 	return hostCmd
@@ -783,6 +783,89 @@ func TestKBMPowerCmd(t *testing.T) {
 	}
 }
 
+func TestKBMBulkPowerCmd(t *testing.T) {
+	testCases := []struct {
+		name           string
+		args           []string
+		configureMock  func(*mocks.MockHostsService, *mocks.MockCollection[serverscom.Host])
+		expectedOutput string
+		expectError    bool
+	}{
+		{
+			name: "power off kbm nodes by selector",
+			args: []string{"kbm", "power", "--selector", "env=test", "--command=off", "--yes"},
+			configureMock: func(mock *mocks.MockHostsService, collection *mocks.MockCollection[serverscom.Host]) {
+				mock.EXPECT().Collection().Return(collection)
+				collection.EXPECT().SetParam("type", "kubernetes_baremetal_node").Return(collection)
+				collection.EXPECT().SetParam("label_selector", "env=test").Return(collection)
+				collection.EXPECT().Collect(gomock.Any()).Return([]serverscom.Host{{ID: "node1"}, {ID: "node2"}}, nil)
+				mock.EXPECT().PowerOffKubernetesBaremetalNode(gomock.Any(), "node1").Return(&testKBM, nil)
+				mock.EXPECT().PowerOffKubernetesBaremetalNode(gomock.Any(), "node2").Return(nil, errors.New("some error"))
+			},
+			expectedOutput: "ID      STATUS    ERROR\nnode1   success   \nnode2   error     some error\n",
+			expectError:    true,
+		},
+		{
+			name: "power off kbm nodes by selector without matches",
+			args: []string{"kbm", "power", "--selector", "env=none", "--command=off", "--yes"},
+			configureMock: func(mock *mocks.MockHostsService, collection *mocks.MockCollection[serverscom.Host]) {
+				mock.EXPECT().Collection().Return(collection)
+				collection.EXPECT().SetParam("type", "kubernetes_baremetal_node").Return(collection)
+				collection.EXPECT().SetParam("label_selector", "env=none").Return(collection)
+				collection.EXPECT().Collect(gomock.Any()).Return(nil, nil)
+			},
+			expectError: true,
+		},
+		{
+			name:        "power on kbm nodes by selector with wait",
+			args:        []string{"kbm", "power", "--selector", "env=test", "--command=on", "--yes", "--wait"},
+			expectError: true,
+		},
+		{
+			name:        "power cycle kbm node with wait",
+			args:        []string{"kbm", "power", testId, "--command=cycle", "--wait"},
+			expectError: true,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	hostsServiceHandler := mocks.NewMockHostsService(mockCtrl)
+	collectionHandler := mocks.NewMockCollection[serverscom.Host](mockCtrl)
+
+	scClient := serverscom.NewClientWithEndpoint("", "")
+	scClient.Hosts = hostsServiceHandler
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			if tc.configureMock != nil {
+				tc.configureMock(hostsServiceHandler, collectionHandler)
+			}
+
+			testCmdContext := testutils.NewTestCmdContext(scClient)
+			kbmCmd := NewKBMCmd(testCmdContext)
+
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(kbmCmd).
+				WithArgs(tc.args)
+
+			err := builder.Build().Execute()
+
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+			if tc.expectedOutput != "" {
+				g.Expect(builder.GetOutput()).To(BeEquivalentTo(tc.expectedOutput))
+			}
+		})
+	}
+}
+
 func expectPowerCall(m *mocks.MockHostsService, action string, id string, s *serverscom.KubernetesBaremetalNode, err error) {
 	calls := map[string]func() *gomock.Call{
 		"on":    func() *gomock.Call { return m.EXPECT().PowerOnKubernetesBaremetalNode(gomock.Any(), id) },
@@ -2,6 +2,7 @@ package hosts
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	serverscom "github.com/serverscom/serverscom-go-client/pkg"
@@ -73,13 +74,30 @@ func (p *SBMPowerMgr) ListPowerFeeds(ctx context.Context, client *serverscom.Cli
 
 func newPowerCmd(cmdContext *base.CmdContext, hostType *HostTypeCmd) *cobra.Command {
 	var commandFlag string
+	waitOpts := &base.WaitOptions{}
+	bulkOpts := &base.BulkOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "power <id>",
 		Short: fmt.Sprintf("Send power command for %s", hostType.entityName),
-		Long:  fmt.Sprintf("Send power command for %s by id", hostType.entityName),
-		Args:  cobra.ExactArgs(1),
+		Long:  fmt.Sprintf("Send power command for %s by id, label selector or ids from file", hostType.entityName),
+		Args:  bulkOpts.Args,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// a host is powered on both before and after a power cycle
+			if waitOpts.Wait && commandFlag == "cycle" {
+				return errors.New("--wait can't be used with --command=cycle")
+			}
+			if bulkOpts.IsBulk() {
+				if waitOpts.Wait {
+					return errors.New("--wait can't be used with --selector or --ids-from")
+				}
+				return bulkOpts.Run(cmd, cmdContext, fmt.Sprintf("Power %s", commandFlag), hostIDsSelector(hostType.typeFlag),
+					func(ctx context.Context, client *serverscom.Client, id string) error {
+						_, err := hostType.managers.powerMgr.PowerAction(ctx, client, id, commandFlag)
+						return err
+					})
+			}
+
 			manager := cmdContext.GetManager()
 
 			ctx, cancel := base.SetupContext(cmd, manager)
@@ -97,6 +115,13 @@ func newPowerCmd(cmdContext *base.CmdContext, hostType *HostTypeCmd) *cobra.Comm
 
 			if server != nil {
 				formatter := cmdContext.GetOrCreateFormatter(cmd)
+				if waitOpts.Wait {
+					result, err := waitForHosts(cmd, hostType.managers.getMgr, cmdContext, waitOpts, server, hostPowerCondition(commandFlag))
+					if err != nil {
+						return err
+					}
+					return formatter.Format(result)
+				}
 				return formatter.Format(server)
 			}
 			return nil
@@ -104,6 +129,8 @@ func newPowerCmd(cmdContext *base.CmdContext, hostType *HostTypeCmd) *cobra.Comm
 	}
 
 	cmd.Flags().StringVar(&commandFlag, "command", "", "power command")
+	waitOpts.AddFlags(cmd)
+	bulkOpts.AddFlags(cmd)
 
 	return cmd
 }
@@ -48,6 +48,7 @@ func (c *SBMReinstallMgr) NewReinstallInput() any {
 
 func newReinstallCmd(cmdContext *base.CmdContext, hostType *HostTypeCmd) *cobra.Command {
 	flags := &AddedFlags{}
+	waitOpts := &base.WaitOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "reinstall <id>",
@@ -87,6 +88,13 @@ func newReinstallCmd(cmdContext *base.CmdContext, hostType *HostTypeCmd) *cobra.
 			}
 
 			if server != nil {
+				if waitOpts.Wait {
+					result, err := waitForHosts(cmd, hostType.managers.getMgr, cmdContext, waitOpts, server, hostReady)
+					if err != nil {
+						return err
+					}
+					return formatter.Format(result)
+				}
 				return formatter.Format(server)
 			}
 			return nil
@@ -95,6 +103,7 @@ func newReinstallCmd(cmdContext *base.CmdContext, hostType *HostTypeCmd) *cobra.
 
 	cmd.Flags().StringVarP(&flags.InputPath, "input", "i", "", "path to input file or '-' to read from stdin")
 	cmd.Flags().BoolVarP(&flags.Skeleton, "skeleton", "s", false, "JSON object with structure that is required to be passed")
+	waitOpts.AddFlags(cmd)
 
 	return cmd
 }
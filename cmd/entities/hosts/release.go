@@ -37,12 +37,19 @@ func newEBMAbortReleaseCmd(cmdContext *base.CmdContext) *cobra.Command {
 
 func newEBMScheduleReleaseCmd(cmdContext *base.CmdContext) *cobra.Command {
 	var releaseAfter string
+	confirmOpts := &base.ConfirmOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "schedule-release <id>",
 		Short: "Schedule release for an enterprise bare metal server",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			getMgr := &EBMGetMgr{}
+			if err := confirmOpts.ConfirmResource(cmd, cmdContext, "Release", "enterprise bare metal server", id, getMgr.Get); err != nil {
+				return err
+			}
+
 			manager := cmdContext.GetManager()
 			ctx, cancel := base.SetupContext(cmd, manager)
 			defer cancel()
@@ -50,7 +57,6 @@ func newEBMScheduleReleaseCmd(cmdContext *base.CmdContext) *cobra.Command {
 			base.SetupProxy(cmd, manager)
 			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
 
-			id := args[0]
 			input := serverscom.ScheduleReleaseInput{ReleaseAfter: releaseAfter}
 			server, err := scClient.Hosts.ScheduleReleaseForDedicatedServer(ctx, id, input)
 			if err != nil {
@@ -66,16 +72,25 @@ func newEBMScheduleReleaseCmd(cmdContext *base.CmdContext) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&releaseAfter, "release-after", "", "UTC datetime string in format: YYYY-MM-DDTHH:MM:SS+HH:MM")
+	confirmOpts.AddFlags(cmd)
 
 	return cmd
 }
 
 func newSBMReleaseCmd(cmdContext *base.CmdContext) *cobra.Command {
+	confirmOpts := &base.ConfirmOptions{}
+
 	cmd := &cobra.Command{
 		Use:   "release <id>",
 		Short: "Release an SBM server",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			getMgr := &SBMGetMgr{}
+			if err := confirmOpts.ConfirmResource(cmd, cmdContext, "Release", "SBM server", id, getMgr.Get); err != nil {
+				return err
+			}
+
 			manager := cmdContext.GetManager()
 			ctx, cancel := base.SetupContext(cmd, manager)
 			defer cancel()
@@ -83,7 +98,6 @@ func newSBMReleaseCmd(cmdContext *base.CmdContext) *cobra.Command {
 			base.SetupProxy(cmd, manager)
 			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
 
-			id := args[0]
 			server, err := scClient.Hosts.ReleaseSBMServer(ctx, id)
 			if err != nil {
 				return err
@@ -97,5 +111,7 @@ func newSBMReleaseCmd(cmdContext *base.CmdContext) *cobra.Command {
 		},
 	}
 
+	confirmOpts.AddFlags(cmd)
+
 	return cmd
 }
@@ -493,7 +493,7 @@ func TestReleaseSBMCmd(t *testing.T) {
 			testCmdContext := testutils.NewTestCmdContext(scClient)
 			sbmCmd := NewSBMCmd(testCmdContext)
 
-			args := []string{"sbm", "release", tc.id}
+			args := []string{"sbm", "release", tc.id, "--yes"}
 			if tc.output != "" {
 				args = append(args, "--output", tc.output)
 			}
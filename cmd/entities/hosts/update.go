@@ -1,6 +1,7 @@
 package hosts
 
 import (
+	"context"
 	"log"
 
 	serverscom "github.com/serverscom/serverscom-go-client/pkg"
@@ -13,17 +14,13 @@ import (
 
 func newUpdateEBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 	var labels []string
+	bulkOpts := &base.BulkOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "update <id>",
 		Short: "Update enterprise bare metal server",
-		Args:  cobra.ExactArgs(1),
+		Args:  bulkOpts.Args,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			manager := cmdContext.GetManager()
-			ctx, cancel := base.SetupContext(cmd, manager)
-			defer cancel()
-			base.SetupProxy(cmd, manager)
-
 			labelsMap, err := base.ParseLabels(labels)
 			if err != nil {
 				log.Fatal(err)
@@ -32,6 +29,19 @@ func newUpdateEBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 				Labels: labelsMap,
 			}
 
+			if bulkOpts.IsBulk() {
+				return bulkOpts.Run(cmd, cmdContext, "Update", hostIDsSelector("dedicated_server"),
+					func(ctx context.Context, client *serverscom.Client, id string) error {
+						_, err := client.Hosts.UpdateDedicatedServer(ctx, id, input)
+						return err
+					})
+			}
+
+			manager := cmdContext.GetManager()
+			ctx, cancel := base.SetupContext(cmd, manager)
+			defer cancel()
+			base.SetupProxy(cmd, manager)
+
 			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
 			id := args[0]
 
@@ -49,23 +59,20 @@ func newUpdateEBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 	}
 
 	cmd.Flags().StringArrayVarP(&labels, "label", "l", []string{}, "string in key=value format")
+	bulkOpts.AddFlags(cmd)
 
 	return cmd
 }
 
 func newUpdateKBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 	var labels []string
+	bulkOpts := &base.BulkOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "update <id>",
 		Short: "Update kubernetes baremetal node",
-		Args:  cobra.ExactArgs(1),
+		Args:  bulkOpts.Args,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			manager := cmdContext.GetManager()
-			ctx, cancel := base.SetupContext(cmd, manager)
-			defer cancel()
-			base.SetupProxy(cmd, manager)
-
 			labelsMap, err := base.ParseLabels(labels)
 			if err != nil {
 				log.Fatal(err)
@@ -74,6 +81,19 @@ func newUpdateKBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 				Labels: labelsMap,
 			}
 
+			if bulkOpts.IsBulk() {
+				return bulkOpts.Run(cmd, cmdContext, "Update", hostIDsSelector("kubernetes_baremetal_node"),
+					func(ctx context.Context, client *serverscom.Client, id string) error {
+						_, err := client.Hosts.UpdateKubernetesBaremetalNode(ctx, id, input)
+						return err
+					})
+			}
+
+			manager := cmdContext.GetManager()
+			ctx, cancel := base.SetupContext(cmd, manager)
+			defer cancel()
+			base.SetupProxy(cmd, manager)
+
 			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
 			id := args[0]
 
@@ -91,23 +111,20 @@ func newUpdateKBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 	}
 
 	cmd.Flags().StringArrayVarP(&labels, "label", "l", []string{}, "string in key=value format")
+	bulkOpts.AddFlags(cmd)
 
 	return cmd
 }
 
 func newUpdateSBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 	var labels []string
+	bulkOpts := &base.BulkOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "update <id>",
 		Short: "Update scalable baremetal server",
-		Args:  cobra.ExactArgs(1),
+		Args:  bulkOpts.Args,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			manager := cmdContext.GetManager()
-			ctx, cancel := base.SetupContext(cmd, manager)
-			defer cancel()
-			base.SetupProxy(cmd, manager)
-
 			labelsMap, err := base.ParseLabels(labels)
 			if err != nil {
 				log.Fatal(err)
@@ -116,6 +133,19 @@ func newUpdateSBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 				Labels: labelsMap,
 			}
 
+			if bulkOpts.IsBulk() {
+				return bulkOpts.Run(cmd, cmdContext, "Update", hostIDsSelector("sbm_server"),
+					func(ctx context.Context, client *serverscom.Client, id string) error {
+						_, err := client.Hosts.UpdateSBMServer(ctx, id, input)
+						return err
+					})
+			}
+
+			manager := cmdContext.GetManager()
+			ctx, cancel := base.SetupContext(cmd, manager)
+			defer cancel()
+			base.SetupProxy(cmd, manager)
+
 			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
 			id := args[0]
 
@@ -133,6 +163,7 @@ func newUpdateSBMCmd(cmdContext *base.CmdContext) *cobra.Command {
 	}
 
 	cmd.Flags().StringArrayVarP(&labels, "label", "l", []string{}, "string in key=value format")
+	bulkOpts.AddFlags(cmd)
 
 	return cmd
 }
@@ -1,6 +1,7 @@
 package hosts
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"sort"
@@ -8,8 +9,17 @@ import (
 	"strings"
 
 	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/cmd/base"
 )
 
+// hostIDsSelector returns bulk select func for hosts of the specified type
+func hostIDsSelector(hostType string) base.BulkSelectFunc {
+	return func(ctx context.Context, client *serverscom.Client, selector string) ([]string, error) {
+		collection := client.Hosts.Collection().SetParam("type", hostType)
+		return base.SelectIDs(ctx, collection, selector, func(h serverscom.Host) string { return h.ID })
+	}
+}
+
 type parsedPartition struct {
 	Slots     []int
 	Partition serverscom.DedicatedServerLayoutPartitionInput
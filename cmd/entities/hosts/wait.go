@@ -0,0 +1,154 @@
+package hosts
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/internal/output/utils"
+	"github.com/spf13/cobra"
+)
+
+// failedHostStatuses are statuses which mean that the operation won't complete
+var failedHostStatuses = []string{"failed", "error"}
+
+// hostState represents host statuses tracked while waiting
+type hostState struct {
+	Status            string
+	OperationalStatus string
+	PowerStatus       string
+}
+
+func (s hostState) String() string {
+	return fmt.Sprintf("status=%s, operational status=%s, power status=%s", s.Status, s.OperationalStatus, s.PowerStatus)
+}
+
+// hostCondition reports whether host has reached the desired state
+type hostCondition func(s hostState) bool
+
+// hostReady is met when host is provisioned and no operation is in progress
+func hostReady(s hostState) bool {
+	return s.Status == "active" && s.OperationalStatus == "normal"
+}
+
+// hostPowerCondition returns condition for the power command. Power cycle
+// isn't supported, the host is powered on before and after it.
+func hostPowerCondition(action string) hostCondition {
+	want := "powered_on"
+	if action == "off" {
+		want = "powered_off"
+	}
+	return func(s hostState) bool {
+		return s.PowerStatus == want && s.OperationalStatus == "normal"
+	}
+}
+
+// waitedHost is a host polled by waitForHosts
+type waitedHost struct {
+	id    string
+	host  any
+	state hostState
+	done  bool
+}
+
+// waitForHosts polls hosts from v until cond is met for all of them and returns
+// refreshed hosts in the same shape as v: a single host or a slice of hosts.
+// All hosts are checked in each iteration and share the wait timeout.
+func waitForHosts(cmd *cobra.Command, getter HostGetter, cmdContext *base.CmdContext, opts *base.WaitOptions, v any, cond hostCondition) (any, error) {
+	rv := reflect.ValueOf(v)
+
+	items := []any{v}
+	if rv.Kind() == reflect.Slice {
+		items = make([]any, 0, rv.Len())
+		for i := range rv.Len() {
+			items = append(items, rv.Index(i).Interface())
+		}
+	}
+
+	hosts := make([]*waitedHost, 0, len(items))
+	for _, item := range items {
+		id, err := utils.GetFieldStringValue(item, "ID")
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, &waitedHost{id: id, host: item})
+	}
+
+	manager := cmdContext.GetManager()
+	scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	err := base.Poll(ctx, opts, func(ctx context.Context) (bool, error) {
+		done := true
+		for _, h := range hosts {
+			if h.done {
+				continue
+			}
+			host, err := getter.Get(ctx, scClient, h.id)
+			if err != nil {
+				return false, fmt.Errorf("failed to get host %s: %w", h.id, err)
+			}
+			h.host = host
+
+			state, err := getHostState(host)
+			if err != nil {
+				return false, err
+			}
+			if state != h.state {
+				h.state = state
+				fmt.Fprintf(cmd.ErrOrStderr(), "Waiting for host %s: %s\n", h.id, state)
+			}
+
+			if slices.Contains(failedHostStatuses, state.Status) || slices.Contains(failedHostStatuses, state.OperationalStatus) {
+				return false, fmt.Errorf("host %s is in failed state: %s", h.id, state)
+			}
+			if h.done = cond(state); h.done {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Host %s is ready\n", h.id)
+				continue
+			}
+			done = false
+		}
+		return done, nil
+	})
+	if err != nil {
+		if len(hosts) == 1 {
+			return nil, fmt.Errorf("failed to wait for host %s: %w", hosts[0].id, err)
+		}
+		return nil, fmt.Errorf("failed to wait for hosts: %w", err)
+	}
+
+	if rv.Kind() != reflect.Slice {
+		return hosts[0].host, nil
+	}
+
+	// rebuild typed slice so the formatter could find the entity
+	out := reflect.MakeSlice(rv.Type(), 0, len(hosts))
+	for _, h := range hosts {
+		out = reflect.Append(out, reflect.Indirect(reflect.ValueOf(h.host)))
+	}
+	return out.Interface(), nil
+}
+
+// getHostState extracts statuses from host
+func getHostState(host any) (hostState, error) {
+	var (
+		s   hostState
+		err error
+	)
+	if s.Status, err = utils.GetFieldStringValue(host, "Status"); err != nil {
+		return s, err
+	}
+	if s.OperationalStatus, err = utils.GetFieldStringValue(host, "OperationalStatus"); err != nil {
+		return s, err
+	}
+	if s.PowerStatus, err = utils.GetFieldStringValue(host, "PowerStatus"); err != nil {
+		return s, err
+	}
+	return s, nil
+}
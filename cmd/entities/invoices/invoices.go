@@ -1,6 +1,8 @@
 package invoices
 
 import (
+	"fmt"
+
 	"log"
 
 	serverscom "github.com/serverscom/serverscom-go-client/pkg"
@@ -32,6 +34,15 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newGetCmd(cmdContext),
 	)
 
+	base.SetIDCompletion(cmd, base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.InvoiceList], error) {
+			return client.Invoices.Collection(), nil
+		},
+		func(i serverscom.InvoiceList) cobra.Completion {
+			return cobra.CompletionWithDesc(i.ID, fmt.Sprintf("#%d", i.Number))
+		},
+	))
+
 	base.AddFormatFlags(cmd)
 
 	return cmd
@@ -3,6 +3,7 @@ package k8s
 import (
 	"log"
 
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
 	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/spf13/cobra"
 )
@@ -76,5 +77,15 @@ func newGetNodeCmd(cmdContext *base.CmdContext) *cobra.Command {
 		log.Fatal(err)
 	}
 
+	cmd.ValidArgsFunction = base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.KubernetesClusterNode], error) {
+			return client.KubernetesClusters.Nodes(clusterID), nil
+		},
+		func(n serverscom.KubernetesClusterNode) cobra.Completion {
+			return cobra.CompletionWithDesc(n.ID, n.Hostname)
+		},
+	)
+	_ = cmd.RegisterFlagCompletionFunc("cluster-id", base.CompleteResources(cmdContext, "cluster-id", completeClusters))
+
 	return cmd
 }
@@ -34,6 +34,8 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newUpdateCmd(cmdContext),
 	)
 
+	base.SetIDCompletion(cmd, base.CompleteIDs(cmdContext, clusterCollection, clusterCompletion))
+
 	base.AddFormatFlags(cmd)
 
 	return cmd
@@ -57,3 +59,14 @@ func getK8sEntities() (map[string]entities.EntityInterface, error) {
 
 	return result, nil
 }
+
+// completeClusters completes kubernetes cluster ids
+var completeClusters = base.CollectionCompletion(clusterCollection, clusterCompletion)
+
+func clusterCollection(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.KubernetesCluster], error) {
+	return client.KubernetesClusters.Collection(), nil
+}
+
+func clusterCompletion(c serverscom.KubernetesCluster) cobra.Completion {
+	return cobra.CompletionWithDesc(c.ID, c.Name)
+}
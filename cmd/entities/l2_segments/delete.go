@@ -1,17 +1,30 @@
 package l2segments
 
 import (
+	"context"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
 	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/spf13/cobra"
 )
 
 func newDeleteCmd(cmdContext *base.CmdContext) *cobra.Command {
+	confirmOpts := &base.ConfirmOptions{}
+
 	cmd := &cobra.Command{
 		Use:   "delete <l2_segment_id>",
 		Short: "Delete an L2 segment",
 		Long:  "Delete an L2 segment by id",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			l2SegmentID := args[0]
+			getFn := func(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+				return client.L2Segments.Get(ctx, id)
+			}
+			if err := confirmOpts.ConfirmResource(cmd, cmdContext, "Delete", "L2 segment", l2SegmentID, getFn); err != nil {
+				return err
+			}
+
 			manager := cmdContext.GetManager()
 
 			ctx, cancel := base.SetupContext(cmd, manager)
@@ -21,10 +34,11 @@ func newDeleteCmd(cmdContext *base.CmdContext) *cobra.Command {
 
 			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
 
-			l2SegmentID := args[0]
 			return scClient.L2Segments.Delete(ctx, l2SegmentID)
 		},
 	}
 
+	confirmOpts.AddFlags(cmd)
+
 	return cmd
 }
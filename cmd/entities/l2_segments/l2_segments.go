@@ -44,6 +44,16 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newDeleteCmd(cmdContext),
 	)
 
+	collection := func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.L2Segment], error) {
+		return client.L2Segments.Collection(), nil
+	}
+	base.SetIDCompletion(cmd, base.CompleteIDs(cmdContext, collection,
+		func(s serverscom.L2Segment) cobra.Completion { return cobra.CompletionWithDesc(s.ID, s.Name) },
+	))
+	base.SetIDResolution(cmd, cmdContext, base.NewIDResolver("L2 segment", collection,
+		func(s serverscom.L2Segment) (string, string) { return s.ID, s.Name },
+	))
+
 	base.AddFormatFlags(cmd)
 
 	return cmd
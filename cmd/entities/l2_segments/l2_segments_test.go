@@ -151,6 +151,120 @@ func TestGetL2SegmentCmd(t *testing.T) {
 	}
 }
 
+func TestGetL2SegmentByNameCmd(t *testing.T) {
+	notFoundErr := &serverscom.NotFoundError{StatusCode: 404, Message: "not found"}
+	otherL2Segment := testL2Segment
+	otherL2Segment.ID = "otherId"
+	similarL2Segment := testL2Segment
+	similarL2Segment.ID = "similarId"
+	similarL2Segment.Name = testL2SegmentName + "-2"
+
+	testCases := []struct {
+		name          string
+		arg           string
+		configureMock func(*mocks.MockL2SegmentsService, *mocks.MockCollection[serverscom.L2Segment])
+		expectedError string
+	}{
+		{
+			name: "get l2 segment with name prefix",
+			arg:  "name:" + testL2SegmentName,
+			configureMock: func(service *mocks.MockL2SegmentsService, collection *mocks.MockCollection[serverscom.L2Segment]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return([]serverscom.L2Segment{similarL2Segment, testL2Segment}, nil)
+				service.EXPECT().
+					Get(gomock.Any(), testID).
+					Return(&testL2Segment, nil)
+			},
+		},
+		{
+			name: "get l2 segment by name which can't be an id",
+			arg:  "test segment",
+			configureMock: func(service *mocks.MockL2SegmentsService, collection *mocks.MockCollection[serverscom.L2Segment]) {
+				namedL2Segment := testL2Segment
+				namedL2Segment.Name = "test segment"
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return([]serverscom.L2Segment{namedL2Segment}, nil)
+				service.EXPECT().
+					Get(gomock.Any(), testID).
+					Return(&testL2Segment, nil)
+			},
+		},
+		{
+			name: "get l2 segment with unknown id",
+			arg:  "unknown",
+			configureMock: func(service *mocks.MockL2SegmentsService, collection *mocks.MockCollection[serverscom.L2Segment]) {
+				service.EXPECT().
+					Get(gomock.Any(), "unknown").
+					Return(nil, notFoundErr)
+			},
+			expectedError: "not found",
+		},
+		{
+			name: "get l2 segment with unknown name",
+			arg:  "title:unknown",
+			configureMock: func(service *mocks.MockL2SegmentsService, collection *mocks.MockCollection[serverscom.L2Segment]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return([]serverscom.L2Segment{similarL2Segment}, nil)
+			},
+			expectedError: `no L2 segment found with name "unknown"`,
+		},
+		{
+			name: "get l2 segment with ambiguous name",
+			arg:  "name:" + testL2SegmentName,
+			configureMock: func(service *mocks.MockL2SegmentsService, collection *mocks.MockCollection[serverscom.L2Segment]) {
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return([]serverscom.L2Segment{testL2Segment, otherL2Segment}, nil)
+			},
+			expectedError: `ambiguous name "testName": matches 2 L2 segments (testId, otherId), use an id instead`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			l2ServiceHandler := mocks.NewMockL2SegmentsService(mockCtrl)
+			collectionHandler := mocks.NewMockCollection[serverscom.L2Segment](mockCtrl)
+
+			l2ServiceHandler.EXPECT().
+				Collection().
+				Return(collectionHandler).
+				AnyTimes()
+			collectionHandler.EXPECT().
+				SetParam("search_pattern", gomock.Any()).
+				Return(collectionHandler).
+				AnyTimes()
+			tc.configureMock(l2ServiceHandler, collectionHandler)
+
+			scClient := serverscom.NewClientWithEndpoint("", "")
+			scClient.L2Segments = l2ServiceHandler
+
+			testCmdContext := testutils.NewTestCmdContext(scClient)
+			l2Cmd := NewCmd(testCmdContext)
+
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(l2Cmd).
+				WithArgs([]string{"l2-segments", "get", tc.arg})
+
+			cmd := builder.Build()
+			err := cmd.Execute()
+
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).To(BeNil())
+				g.Expect(builder.GetOutput()).To(BeEquivalentTo(string(testutils.ReadFixture(filepath.Join(fixtureBasePath, "get.txt")))))
+			}
+		})
+	}
+}
+
 func TestListL2SegmentsCmd(t *testing.T) {
 	testL2Segment1 := testL2Segment
 	testL2Segment2 := testL2Segment
@@ -525,7 +639,7 @@ func TestDeleteL2SegmentCmd(t *testing.T) {
 			testCmdContext := testutils.NewTestCmdContext(scClient)
 			l2Cmd := NewCmd(testCmdContext)
 
-			args := []string{"l2-segments", "delete", tc.id}
+			args := []string{"l2-segments", "delete", tc.id, "--yes"}
 			builder := testutils.NewTestCommandBuilder().
 				WithCommand(l2Cmd).
 				WithArgs(args)
@@ -32,6 +32,13 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newGetCmd(cmdContext),
 	)
 
+	base.SetIDCompletion(cmd, base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.LoadBalancerCluster], error) {
+			return client.LoadBalancerClusters.Collection(), nil
+		},
+		func(c serverscom.LoadBalancerCluster) cobra.Completion { return cobra.CompletionWithDesc(c.ID, c.Name) },
+	))
+
 	base.AddFormatFlags(cmd)
 
 	return cmd
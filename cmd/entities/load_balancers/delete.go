@@ -25,12 +25,19 @@ func (d *LBL7DeleteMgr) Delete(ctx context.Context, client *serverscom.Client, i
 }
 
 func newDeleteCmd(cmdContext *base.CmdContext, lbType *LBTypeCmd) *cobra.Command {
+	confirmOpts := &base.ConfirmOptions{}
+
 	cmd := &cobra.Command{
 		Use:   "delete <id>",
 		Short: "Delete load balancer",
 		Long:  "Delete load balancer by id",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			if err := confirmOpts.ConfirmResource(cmd, cmdContext, "Delete", "load balancer", id, lbType.managers.getMgr.Get); err != nil {
+				return err
+			}
+
 			manager := cmdContext.GetManager()
 
 			ctx, cancel := base.SetupContext(cmd, manager)
@@ -40,7 +47,6 @@ func newDeleteCmd(cmdContext *base.CmdContext, lbType *LBTypeCmd) *cobra.Command
 
 			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
 
-			id := args[0]
 			err := lbType.managers.deleteMgr.Delete(ctx, scClient, id)
 			if err != nil {
 				return err
@@ -49,5 +55,8 @@ func newDeleteCmd(cmdContext *base.CmdContext, lbType *LBTypeCmd) *cobra.Command
 			return nil
 		},
 	}
+
+	confirmOpts.AddFlags(cmd)
+
 	return cmd
 }
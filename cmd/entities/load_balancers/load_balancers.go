@@ -107,6 +107,16 @@ func newLBTypeCmd(cmdContext *base.CmdContext, lbType LBTypeCmd) *cobra.Command
 		LBCmd.AddCommand(cmdFunc(cmdContext))
 	}
 
+	collection := func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.LoadBalancer], error) {
+		return client.LoadBalancers.Collection().SetParam("type", lbType.typeFlag), nil
+	}
+	base.SetIDCompletion(LBCmd, base.CompleteIDs(cmdContext, collection,
+		func(i serverscom.LoadBalancer) cobra.Completion { return cobra.CompletionWithDesc(i.ID, i.Name) },
+	))
+	base.SetIDResolution(LBCmd, cmdContext, base.NewIDResolver("load balancer", collection,
+		func(i serverscom.LoadBalancer) (string, string) { return i.ID, i.Name },
+	))
+
 	return LBCmd
 }
 
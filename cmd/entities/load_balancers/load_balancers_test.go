@@ -759,7 +759,7 @@ func TestDeleteL4LBCmd(t *testing.T) {
 			testCmdContext := testutils.NewTestCmdContext(scClient)
 			lbCmd := NewCmd(testCmdContext)
 
-			args := []string{"lb", "l4", "delete", tc.id}
+			args := []string{"lb", "l4", "delete", tc.id, "--yes"}
 			builder := testutils.NewTestCommandBuilder().
 				WithCommand(lbCmd).
 				WithArgs(args)
@@ -817,7 +817,7 @@ func TestDeleteL7LBCmd(t *testing.T) {
 			testCmdContext := testutils.NewTestCmdContext(scClient)
 			lbCmd := NewCmd(testCmdContext)
 
-			args := []string{"lb", "l7", "delete", tc.id}
+			args := []string{"lb", "l7", "delete", tc.id, "--yes"}
 			builder := testutils.NewTestCommandBuilder().
 				WithCommand(lbCmd).
 				WithArgs(args)
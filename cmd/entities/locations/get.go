@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
 	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/spf13/cobra"
 )
@@ -41,5 +42,12 @@ func newGetCmd(cmdContext *base.CmdContext) *cobra.Command {
 		},
 	}
 
+	cmd.ValidArgsFunction = base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.Location], error) {
+			return client.Locations.Collection(), nil
+		},
+		func(l serverscom.Location) cobra.Completion { return base.Int64Completion(l.ID, l.Name) },
+	)
+
 	return cmd
 }
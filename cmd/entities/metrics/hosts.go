@@ -23,7 +23,7 @@ func newHostsCmd(cmdContext *base.CmdContext) *cobra.Command {
 		Long: "Get hosts metrics: monthly traffic per host.\n\n" +
 			"Use --output raw to get metrics in the Prometheus text exposition format as returned by the API.",
 		PersistentPreRunE: base.CombinePreRunE(
-			base.CheckFormatterFlagsWithOutputs(cmdContext, entitiesMap, []string{rawOutput}),
+			base.CheckFormatterFlagsWithOutputs(cmdContext, entitiesMap, []string{rawOutput, ndjsonOutput}),
 			checkPaginationFlags(cmdContext),
 		),
 		Args: base.NoArgs,
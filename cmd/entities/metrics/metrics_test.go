@@ -59,6 +59,24 @@ func TestHostsCmd(t *testing.T) {
 			metrics:        hostsMetrics,
 			expectedOutput: readFixture("hosts_field.txt"),
 		},
+		{
+			name:           "get hosts metrics in CSV format with fields",
+			args:           []string{"--output", "csv", "-f", "HostID", "-f", "ChassisName", "-f", "TotalSent"},
+			metrics:        hostsMetrics,
+			expectedOutput: readFixture("hosts_field.csv"),
+		},
+		{
+			name:           "get hosts metrics in TSV format without header",
+			args:           []string{"--output", "tsv", "--no-header"},
+			metrics:        hostsMetrics,
+			expectedOutput: readFixture("hosts_no_header.tsv"),
+		},
+		{
+			name:        "get hosts metrics in CSV format with page view",
+			args:        []string{"--output", "csv", "--page-view"},
+			noAPICall:   true,
+			expectError: true,
+		},
 		{
 			name:           "get hosts metrics with template",
 			args:           []string{"-t", `{{range .}}{{.HostID}} {{.TotalSent}}\n{{end}}`},
@@ -82,6 +100,12 @@ func TestHostsCmd(t *testing.T) {
 			metrics:        "",
 			expectedOutput: readFixture("hosts_empty.txt"),
 		},
+		{
+			name:           "get all hosts metrics in NDJSON format",
+			args:           []string{"--output", "ndjson", "-A"},
+			metrics:        hostsMetrics,
+			expectedOutput: readFixture("hosts.ndjson"),
+		},
 		{
 			name:           "get hosts metrics in raw format",
 			args:           []string{"--output", "raw"},
@@ -191,6 +215,12 @@ func TestRacksCmd(t *testing.T) {
 			metrics:        racksMetrics,
 			expectedOutput: readFixture("racks.txt"),
 		},
+		{
+			name:           "get racks metrics in NDJSON format",
+			args:           []string{"--output", "ndjson"},
+			metrics:        racksMetrics,
+			expectedOutput: readFixture("racks.ndjson"),
+		},
 		{
 			name:           "get empty racks metrics",
 			metrics:        "",
@@ -23,7 +23,7 @@ func newRacksCmd(cmdContext *base.CmdContext) *cobra.Command {
 		Long: "Get private racks metrics: hosts count, monthly traffic and PDU/ATS power draw per rack.\n\n" +
 			"Use --output raw to get metrics in the Prometheus text exposition format as returned by the API.",
 		PersistentPreRunE: base.CombinePreRunE(
-			base.CheckFormatterFlagsWithOutputs(cmdContext, entitiesMap, []string{rawOutput}),
+			base.CheckFormatterFlagsWithOutputs(cmdContext, entitiesMap, []string{rawOutput, ndjsonOutput}),
 			checkPaginationFlags(cmdContext),
 		),
 		Args: base.NoArgs,
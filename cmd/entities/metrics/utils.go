@@ -10,6 +10,8 @@ import (
 const (
 	// rawOutput prints metrics as returned by the API
 	rawOutput = "raw"
+	// ndjsonOutput prints one JSON object per row
+	ndjsonOutput = "ndjson"
 
 	// defaultPerPage limits the number of rows printed by default. All the metrics
 	// come in a single response, so unlike the list commands there is no page size
@@ -22,7 +24,7 @@ func addFlags(cmd *cobra.Command) {
 	base.AddFormatFlags(cmd)
 
 	// shadows the global output flag, as metrics support their own set of formats
-	cmd.PersistentFlags().StringP("output", "o", "text", "output format (text/raw)")
+	cmd.PersistentFlags().StringP("output", "o", "text", "output format (text/csv/tsv/ndjson/raw)")
 
 	flags := cmd.Flags()
 	flags.Int("per-page", defaultPerPage, "Number of items per page")
@@ -1,12 +1,16 @@
 package networkpools
 
 import (
+	"context"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
 	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/spf13/cobra"
 )
 
 func newDeleteSubnetCmd(cmdContext *base.CmdContext) *cobra.Command {
 	var subnetID string
+	confirmOpts := &base.ConfirmOptions{}
 
 	cmd := &cobra.Command{
 		Use:   "delete <network_pool_id>",
@@ -14,6 +18,14 @@ func newDeleteSubnetCmd(cmdContext *base.CmdContext) *cobra.Command {
 		Long:  "Delete a subnetwork for a network pool by id",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			networkPoolID := args[0]
+			getFn := func(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+				return client.NetworkPools.GetSubnetwork(ctx, networkPoolID, id)
+			}
+			if err := confirmOpts.ConfirmResource(cmd, cmdContext, "Delete", "subnetwork", subnetID, getFn); err != nil {
+				return err
+			}
+
 			manager := cmdContext.GetManager()
 
 			ctx, cancel := base.SetupContext(cmd, manager)
@@ -23,13 +35,13 @@ func newDeleteSubnetCmd(cmdContext *base.CmdContext) *cobra.Command {
 
 			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
 
-			networkPoolID := args[0]
 			return scClient.NetworkPools.DeleteSubnetwork(ctx, networkPoolID, subnetID)
 		},
 	}
 
 	cmd.Flags().StringVar(&subnetID, "network-id", "", "Subnetwork id (string, required)")
 	_ = cmd.MarkFlagRequired("network-id")
+	confirmOpts.AddFlags(cmd)
 
 	return cmd
 }
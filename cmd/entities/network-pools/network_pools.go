@@ -38,6 +38,19 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newDeleteSubnetCmd(cmdContext),
 	)
 
+	base.SetIDCompletion(cmd, base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.NetworkPool], error) {
+			return client.NetworkPools.Collection(), nil
+		},
+		func(p serverscom.NetworkPool) cobra.Completion {
+			desc := p.CIDR
+			if p.Title != nil && *p.Title != "" {
+				desc = *p.Title
+			}
+			return cobra.CompletionWithDesc(p.ID, desc)
+		},
+	))
+
 	base.AddFormatFlags(cmd)
 
 	return cmd
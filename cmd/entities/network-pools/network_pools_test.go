@@ -675,7 +675,7 @@ func TestDeleteSubnetCmd(t *testing.T) {
 			testCmdContext := testutils.NewTestCmdContext(scClient)
 			cmd := NewCmd(testCmdContext)
 
-			args := []string{"network-pools", "delete", tc.networkID, "--network-id", tc.subnetID}
+			args := []string{"network-pools", "delete", tc.networkID, "--network-id", tc.subnetID, "--yes"}
 			builder := testutils.NewTestCommandBuilder().
 				WithCommand(cmd).
 				WithArgs(args)
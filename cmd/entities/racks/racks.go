@@ -33,6 +33,13 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newUpdateCmd(cmdContext),
 	)
 
+	base.SetIDCompletion(cmd, base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.Rack], error) {
+			return client.Racks.Collection(), nil
+		},
+		func(r serverscom.Rack) cobra.Completion { return cobra.CompletionWithDesc(r.ID, r.Name) },
+	))
+
 	base.AddFormatFlags(cmd)
 
 	return cmd
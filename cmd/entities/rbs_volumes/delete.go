@@ -1,17 +1,37 @@
 package rbsvolumes
 
 import (
+	"context"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
 	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/spf13/cobra"
 )
 
 func newDeleteCmd(cmdContext *base.CmdContext) *cobra.Command {
+	bulkOpts := &base.BulkOptions{}
+
 	cmd := &cobra.Command{
 		Use:   "delete <volume-id>",
 		Short: "Delete a RBS volume",
 		Long:  "Delete a Remote Block Storage volume by ID",
-		Args:  cobra.ExactArgs(1),
+		Args:  bulkOpts.Args,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if bulkOpts.IsBulk() {
+				return bulkOpts.Run(cmd, cmdContext, "Delete", selectVolumeIDs,
+					func(ctx context.Context, client *serverscom.Client, id string) error {
+						return client.RemoteBlockStorageVolumes.Delete(ctx, id)
+					})
+			}
+
+			volumeID := args[0]
+			getFn := func(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+				return client.RemoteBlockStorageVolumes.Get(ctx, id)
+			}
+			if err := bulkOpts.ConfirmResource(cmd, cmdContext, "Delete", "RBS volume", volumeID, getFn); err != nil {
+				return err
+			}
+
 			manager := cmdContext.GetManager()
 
 			ctx, cancel := base.SetupContext(cmd, manager)
@@ -21,10 +41,17 @@ func newDeleteCmd(cmdContext *base.CmdContext) *cobra.Command {
 
 			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
 
-			volumeID := args[0]
 			return scClient.RemoteBlockStorageVolumes.Delete(ctx, volumeID)
 		},
 	}
 
+	bulkOpts.AddFlags(cmd)
+
 	return cmd
 }
+
+// selectVolumeIDs returns ids of RBS volumes matching label selector
+func selectVolumeIDs(ctx context.Context, client *serverscom.Client, selector string) ([]string, error) {
+	return base.SelectIDs(ctx, client.RemoteBlockStorageVolumes.Collection(), selector,
+		func(v serverscom.RemoteBlockStorageVolume) string { return v.ID })
+}
@@ -42,6 +42,18 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newResetCredentialsCmd(cmdContext),
 	)
 
+	collection := func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.RemoteBlockStorageVolume], error) {
+		return client.RemoteBlockStorageVolumes.Collection(), nil
+	}
+	base.SetIDCompletion(cmd, base.CompleteIDs(cmdContext, collection,
+		func(v serverscom.RemoteBlockStorageVolume) cobra.Completion {
+			return cobra.CompletionWithDesc(v.ID, v.Name)
+		},
+	))
+	base.SetIDResolution(cmd, cmdContext, base.NewIDResolver("volume", collection,
+		func(v serverscom.RemoteBlockStorageVolume) (string, string) { return v.ID, v.Name },
+	))
+
 	base.AddFormatFlags(cmd)
 
 	return cmd
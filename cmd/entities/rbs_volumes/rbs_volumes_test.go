@@ -3,7 +3,9 @@ package rbsvolumes
 import (
 	"errors"
 	_ "fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -409,7 +411,7 @@ func TestDeleteRBSVolumeCmd(t *testing.T) {
 			testCmdContext := testutils.NewTestCmdContext(scClient)
 			rbsVolumeCmd := NewCmd(testCmdContext)
 
-			args := []string{"rbs", "delete", tc.volumeID}
+			args := []string{"rbs", "delete", tc.volumeID, "--yes"}
 
 			builder := testutils.NewTestCommandBuilder().
 				WithCommand(rbsVolumeCmd).
@@ -426,6 +428,112 @@ func TestDeleteRBSVolumeCmd(t *testing.T) {
 	}
 }
 
+func TestBulkDeleteRBSVolumesCmd(t *testing.T) {
+	idsFile := filepath.Join(t.TempDir(), "ids.txt")
+	if err := os.WriteFile(idsFile, []byte("testID1\n\n# comment\ntestID2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name           string
+		args           []string
+		input          string
+		configureMock  func(*mocks.MockRemoteBlockStorageVolumesService, *mocks.MockCollection[serverscom.RemoteBlockStorageVolume])
+		expectedOutput string
+		expectError    bool
+	}{
+		{
+			name: "delete rbs volumes by selector",
+			args: []string{"--selector", "env=test", "--yes"},
+			configureMock: func(mock *mocks.MockRemoteBlockStorageVolumesService, collection *mocks.MockCollection[serverscom.RemoteBlockStorageVolume]) {
+				mock.EXPECT().Collection().Return(collection)
+				collection.EXPECT().SetParam("label_selector", "env=test").Return(collection)
+				collection.EXPECT().Collect(gomock.Any()).Return([]serverscom.RemoteBlockStorageVolume{{ID: "testID1"}, {ID: "testID2"}}, nil)
+				mock.EXPECT().Delete(gomock.Any(), "testID1").Return(nil)
+				mock.EXPECT().Delete(gomock.Any(), "testID2").Return(nil)
+			},
+			expectedOutput: `[{"id": "testID1", "status": "success"}, {"id": "testID2", "status": "success"}]`,
+		},
+		{
+			name:  "delete rbs volumes from file with confirmation",
+			args:  []string{"--ids-from", idsFile},
+			input: "y\n",
+			configureMock: func(mock *mocks.MockRemoteBlockStorageVolumesService, collection *mocks.MockCollection[serverscom.RemoteBlockStorageVolume]) {
+				mock.EXPECT().Delete(gomock.Any(), "testID1").Return(nil)
+				mock.EXPECT().Delete(gomock.Any(), "testID2").Return(errors.New("delete error"))
+			},
+			expectedOutput: `[{"id": "testID1", "status": "success"}, {"id": "testID2", "status": "error", "error": "delete error"}]`,
+			expectError:    true,
+		},
+		{
+			name:        "delete rbs volumes from file without confirmation",
+			args:        []string{"--ids-from", idsFile},
+			input:       "n\n",
+			expectError: true,
+		},
+		{
+			name:  "delete rbs volumes from stdin",
+			args:  []string{"--ids-from", "-", "--yes"},
+			input: "testID1\n",
+			configureMock: func(mock *mocks.MockRemoteBlockStorageVolumesService, collection *mocks.MockCollection[serverscom.RemoteBlockStorageVolume]) {
+				mock.EXPECT().Delete(gomock.Any(), "testID1").Return(nil)
+			},
+			expectedOutput: `[{"id": "testID1", "status": "success"}]`,
+		},
+		{
+			name:        "delete rbs volumes from stdin without yes",
+			args:        []string{"--ids-from", "-"},
+			input:       "testID1\n",
+			expectError: true,
+		},
+		{
+			name:        "delete rbs volumes with id and selector",
+			args:        []string{testRBSVolumeID, "--selector", "env=test", "--yes"},
+			expectError: true,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	rbsVolumeServiceHandler := mocks.NewMockRemoteBlockStorageVolumesService(mockCtrl)
+	collectionHandler := mocks.NewMockCollection[serverscom.RemoteBlockStorageVolume](mockCtrl)
+
+	scClient := serverscom.NewClientWithEndpoint("", "")
+	scClient.RemoteBlockStorageVolumes = rbsVolumeServiceHandler
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			if tc.configureMock != nil {
+				tc.configureMock(rbsVolumeServiceHandler, collectionHandler)
+			}
+
+			testCmdContext := testutils.NewTestCmdContext(scClient)
+			rbsVolumeCmd := NewCmd(testCmdContext)
+
+			args := append([]string{"rbs", "delete", "--output", "json"}, tc.args...)
+
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(rbsVolumeCmd).
+				WithArgs(args).
+				WithInput(strings.NewReader(tc.input))
+
+			err := builder.Build().Execute()
+
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+			if tc.expectedOutput != "" {
+				g.Expect(builder.GetOutput()).To(MatchJSON(tc.expectedOutput))
+			}
+		})
+	}
+}
+
 func TestGetRBSVolumeCredentialsCmd(t *testing.T) {
 	testCases := []struct {
 		name           string
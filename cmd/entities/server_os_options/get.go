@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
 	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/spf13/cobra"
 )
@@ -50,5 +51,14 @@ func newGetCmd(cmdContext *base.CmdContext) *cobra.Command {
 	_ = cmd.MarkFlagRequired("location-id")
 	_ = cmd.MarkFlagRequired("server-model-id")
 
+	cmd.ValidArgsFunction = base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.OperatingSystemOption], error) {
+			return client.Locations.OperatingSystemOptions(locationID, serverModelID), nil
+		},
+		func(o serverscom.OperatingSystemOption) cobra.Completion {
+			return base.Int64Completion(o.ID, o.FullName)
+		},
+	)
+
 	return cmd
 }
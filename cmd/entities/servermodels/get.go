@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
 	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/spf13/cobra"
 )
@@ -47,5 +48,12 @@ func newGetCmd(cmdContext *base.CmdContext) *cobra.Command {
 	cmd.Flags().Int64Var(&locationID, "location-id", 0, "Location id (int, required)")
 	_ = cmd.MarkFlagRequired("location-id")
 
+	cmd.ValidArgsFunction = base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.ServerModelOption], error) {
+			return client.Locations.ServerModelOptions(locationID), nil
+		},
+		func(m serverscom.ServerModelOption) cobra.Completion { return base.Int64Completion(m.ID, m.Name) },
+	)
+
 	return cmd
 }
@@ -8,7 +8,9 @@ import (
 
 	. "github.com/onsi/gomega"
 	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/serverscom/srvctl/cmd/testutils"
+	"github.com/serverscom/srvctl/internal/config"
 	"github.com/serverscom/srvctl/internal/mocks"
 	"go.uber.org/mock/gomock"
 )
@@ -273,3 +275,103 @@ func TestListServerModelOptionsCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestServerModelsCompletion(t *testing.T) {
+	testCases := []struct {
+		name           string
+		args           []string
+		expectedOutput string
+		configureMock  func(*mocks.MockLocationsService, *gomock.Controller)
+	}{
+		{
+			name:           "complete server model id",
+			args:           []string{"get", "--location-id", "1", ""},
+			expectedOutput: "100\tserver-model-123\n:4\n",
+			configureMock: func(mock *mocks.MockLocationsService, ctrl *gomock.Controller) {
+				collection := mocks.NewMockCollection[serverscom.ServerModelOption](ctrl)
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return([]serverscom.ServerModelOption{testServerModelOption}, nil)
+				mock.EXPECT().
+					ServerModelOptions(testLocationID).
+					Return(collection)
+			},
+		},
+		{
+			name:           "complete server model id with prefix",
+			args:           []string{"get", "--location-id", "1", "2"},
+			expectedOutput: ":4\n",
+			configureMock: func(mock *mocks.MockLocationsService, ctrl *gomock.Controller) {
+				collection := mocks.NewMockCollection[serverscom.ServerModelOption](ctrl)
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return([]serverscom.ServerModelOption{testServerModelOption}, nil)
+				mock.EXPECT().
+					ServerModelOptions(testLocationID).
+					Return(collection)
+			},
+		},
+		{
+			name:           "complete location id flag",
+			args:           []string{"list", "--location-id", ""},
+			expectedOutput: "1\tAmsterdam\n:4\n",
+			configureMock: func(mock *mocks.MockLocationsService, ctrl *gomock.Controller) {
+				collection := mocks.NewMockCollection[serverscom.Location](ctrl)
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return([]serverscom.Location{{ID: testLocationID, Name: "Amsterdam"}}, nil)
+				mock.EXPECT().
+					Collection().
+					Return(collection)
+			},
+		},
+		{
+			name:           "complete with error",
+			args:           []string{"get", "--location-id", "1", ""},
+			expectedOutput: ":4\n",
+			configureMock: func(mock *mocks.MockLocationsService, ctrl *gomock.Controller) {
+				collection := mocks.NewMockCollection[serverscom.ServerModelOption](ctrl)
+				collection.EXPECT().
+					Collect(gomock.Any()).
+					Return(nil, errors.New("some error"))
+				mock.EXPECT().
+					ServerModelOptions(testLocationID).
+					Return(collection)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			locationsServiceHandler := mocks.NewMockLocationsService(mockCtrl)
+			tc.configureMock(locationsServiceHandler, mockCtrl)
+
+			scClient := serverscom.NewClientWithEndpoint("", "")
+			scClient.Locations = locationsServiceHandler
+
+			testCmdContext := testutils.NewTestCmdContext(scClient)
+			// completions are fetched only for a context with a token
+			testCmdContext.SetManagerConfig(&config.Config{
+				DefaultContext: "test",
+				Contexts:       []config.Context{{Name: "test", Token: "secret"}},
+			})
+			serverModelsCmd := NewCmd(testCmdContext)
+
+			args := append([]string{"__complete", "server-models"}, tc.args...)
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(serverModelsCmd).
+				WithArgs(args)
+
+			cmd := builder.Build()
+			base.RegisterFlagCompletions(cmd, testCmdContext)
+
+			err := cmd.Execute()
+
+			g.Expect(err).To(BeNil())
+			g.Expect(builder.GetOutput()).To(Equal(tc.expectedOutput))
+		})
+	}
+}
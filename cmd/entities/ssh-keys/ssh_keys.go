@@ -35,6 +35,13 @@ func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
 		newDeleteCmd(cmdContext),
 	)
 
+	base.SetIDCompletion(cmd, base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.SSHKey], error) {
+			return client.SSHKeys.Collection(), nil
+		},
+		func(k serverscom.SSHKey) cobra.Completion { return cobra.CompletionWithDesc(k.Fingerprint, k.Name) },
+	))
+
 	base.AddFormatFlags(cmd)
 
 	return cmd
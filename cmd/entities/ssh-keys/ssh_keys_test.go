@@ -158,6 +158,12 @@ func TestGetSSHKeysCmd(t *testing.T) {
 			output:         "yaml",
 			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "get.yaml")),
 		},
+		{
+			name:           "get ssh key in NDJSON format",
+			fingerprint:    testFingerprint,
+			output:         "ndjson",
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "get.ndjson")),
+		},
 		{
 			name:        "get ssh key with error",
 			fingerprint: testFingerprint,
@@ -216,6 +222,10 @@ func TestListSSHKeysCmd(t *testing.T) {
 	testKey2 := testSSHKey
 	testKey2.Name = "test-key 2"
 	testKey2.Fingerprint = "00:00:00:00:00:00:00:00:00:00"
+	testKey3 := testSSHKey
+	testKey3.Name = "dev & ops <key>"
+	testKey4 := testSSHKey
+	testKey4.Name = "ops"
 
 	testCases := []struct {
 		name           string
@@ -264,6 +274,121 @@ func TestListSSHKeysCmd(t *testing.T) {
 					}, nil)
 			},
 		},
+		{
+			name:           "list ssh keys with template keeps special characters",
+			args:           []string{"--template", "{{range .}}{{.Name}}\n{{end}}"},
+			expectedOutput: []byte("dev & ops <key>\n"),
+			configureMock: func(mock *mocks.MockCollection[serverscom.SSHKey]) {
+				mock.EXPECT().
+					List(gomock.Any()).
+					Return([]serverscom.SSHKey{testKey3}, nil)
+			},
+		},
+		{
+			name:           "list ssh keys with jsonpath",
+			output:         `jsonpath={range .items[*]}{.name}{"\t"}{.labels.foo}{"\n"}{end}`,
+			expectedOutput: []byte("test-key\tbar\ndev & ops <key>\tbar\n"),
+			configureMock: func(mock *mocks.MockCollection[serverscom.SSHKey]) {
+				mock.EXPECT().
+					List(gomock.Any()).
+					Return([]serverscom.SSHKey{testKey1, testKey3}, nil)
+			},
+		},
+		{
+			name:           "list ssh keys with jsonpath of items",
+			output:         "jsonpath={.items[*].fingerprint}",
+			expectedOutput: []byte(testKey1.Fingerprint + " " + testKey2.Fingerprint + "\n"),
+			configureMock: func(mock *mocks.MockCollection[serverscom.SSHKey]) {
+				mock.EXPECT().
+					List(gomock.Any()).
+					Return([]serverscom.SSHKey{testKey1, testKey2}, nil)
+			},
+		},
+		{
+			name:           "list ssh keys with jq",
+			output:         `jq=.[] | select(.name | startswith("test")) | .fingerprint`,
+			expectedOutput: []byte(testKey1.Fingerprint + "\n" + testKey2.Fingerprint + "\n"),
+			configureMock: func(mock *mocks.MockCollection[serverscom.SSHKey]) {
+				mock.EXPECT().
+					List(gomock.Any()).
+					Return([]serverscom.SSHKey{testKey1, testKey2, testKey3}, nil)
+			},
+		},
+		{
+			name:        "list ssh keys with jq without expression",
+			output:      "jq",
+			expectError: true,
+		},
+		{
+			name: "list all ssh keys page by page",
+			args: []string{"-A"},
+			expectedOutput: []byte("Name         Fingerprint\n" +
+				"test-key     " + testFingerprint + "\n" +
+				"test-key 2   " + testKey2.Fingerprint + "\n" +
+				"ops   " + testFingerprint + "\n"),
+			configureMock: func(mock *mocks.MockCollection[serverscom.SSHKey]) {
+				expectPages(mock, []serverscom.SSHKey{testKey1, testKey2}, []serverscom.SSHKey{testKey4})
+			},
+		},
+		{
+			name: "list all ssh keys page by page with fixed widths",
+			args: []string{"-A", "--fixed-widths"},
+			expectedOutput: []byte("Name         Fingerprint\n" +
+				"test-key     " + testFingerprint + "\n" +
+				"test-key 2   " + testKey2.Fingerprint + "\n" +
+				"ops          " + testFingerprint + "\n"),
+			configureMock: func(mock *mocks.MockCollection[serverscom.SSHKey]) {
+				expectPages(mock, []serverscom.SSHKey{testKey1, testKey2}, []serverscom.SSHKey{testKey4})
+			},
+		},
+		{
+			name:           "list all ssh keys page by page as csv",
+			output:         "csv",
+			args:           []string{"-A", "--no-header"},
+			expectedOutput: []byte("test-key," + testFingerprint + "\ntest-key 2," + testKey2.Fingerprint + "\nops," + testFingerprint + "\n"),
+			configureMock: func(mock *mocks.MockCollection[serverscom.SSHKey]) {
+				expectPages(mock, []serverscom.SSHKey{testKey1, testKey2}, []serverscom.SSHKey{testKey4})
+			},
+		},
+		{
+			name:           "list all ssh keys page by page as ndjson",
+			output:         "ndjson",
+			args:           []string{"-A"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "list_all.ndjson")),
+			configureMock: func(mock *mocks.MockCollection[serverscom.SSHKey]) {
+				expectPages(mock, []serverscom.SSHKey{testKey1, testKey2}, []serverscom.SSHKey{testKey3})
+			},
+		},
+		{
+			name:           "list ssh keys with filter and sort",
+			args:           []string{"--filter", "name~^test", "--sort-by", "-Name", "--template", "{{range .}}{{.Name}}\n{{end}}"},
+			expectedOutput: []byte("test-key 2\ntest-key\n"),
+			configureMock: func(mock *mocks.MockCollection[serverscom.SSHKey]) {
+				mock.EXPECT().
+					List(gomock.Any()).
+					Return([]serverscom.SSHKey{testKey1, testKey3, testKey2}, nil)
+			},
+		},
+		{
+			name:           "list ssh keys with filter containing commas",
+			args:           []string{"--filter", "name~^test-key( 2|,)$", "--filter", "name!=test-key", "--template", "{{range .}}{{.Name}}\n{{end}}"},
+			expectedOutput: []byte("test-key 2\n"),
+			configureMock: func(mock *mocks.MockCollection[serverscom.SSHKey]) {
+				mock.EXPECT().
+					List(gomock.Any()).
+					Return([]serverscom.SSHKey{testKey1, testKey3, testKey2}, nil)
+			},
+		},
+		{
+			name:        "list ssh keys with invalid filter",
+			args:        []string{"--filter", "unknown=1"},
+			expectError: true,
+		},
+		{
+			name:        "list ssh keys with invalid jsonpath",
+			output:      "jsonpath={.items[*].name",
+			expectError: true,
+		},
 		{
 			name:           "list ssh keys with pageView",
 			args:           []string{"--page-view"},
@@ -490,3 +615,18 @@ func TestDeleteSSHKeysCmd(t *testing.T) {
 		})
 	}
 }
+
+// expectPages expects all pages of the collection to be fetched one by one
+func expectPages[T any](mock *mocks.MockCollection[T], pages ...[]T) {
+	calls := make([]any, 0, len(pages)*2)
+	for i, page := range pages {
+		calls = append(calls,
+			mock.EXPECT().SetPage(i+1).Return(mock),
+			mock.EXPECT().List(gomock.Any()).Return(page, nil),
+		)
+	}
+	gomock.InOrder(calls...)
+
+	mock.EXPECT().IsClean().Return(false)
+	mock.EXPECT().HasNextPage().Return(len(pages) > 1)
+}
@@ -25,12 +25,27 @@ func (d *SSLLeDeleteMgr) Delete(ctx context.Context, client *serverscom.Client,
 }
 
 func newDeleteCmd(cmdContext *base.CmdContext, sslType *SSLTypeCmd) *cobra.Command {
+	bulkOpts := &base.BulkOptions{}
+
 	cmd := &cobra.Command{
 		Use:   "delete <id>",
 		Short: "Delete an SSL certificate",
 		Long:  "Delete an SSL certificate by id",
-		Args:  cobra.ExactArgs(1),
+		Args:  bulkOpts.Args,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if bulkOpts.IsBulk() {
+				selectFn := func(ctx context.Context, client *serverscom.Client, selector string) ([]string, error) {
+					collection := client.SSLCertificates.Collection().SetParam("type", sslType.typeFlag)
+					return base.SelectIDs(ctx, collection, selector, func(c serverscom.SSLCertificate) string { return c.ID })
+				}
+				return bulkOpts.Run(cmd, cmdContext, "Delete", selectFn, sslType.managers.deleteMgr.Delete)
+			}
+
+			id := args[0]
+			if err := bulkOpts.ConfirmResource(cmd, cmdContext, "Delete", "SSL certificate", id, sslType.managers.getMgr.Get); err != nil {
+				return err
+			}
+
 			manager := cmdContext.GetManager()
 
 			ctx, cancel := base.SetupContext(cmd, manager)
@@ -40,7 +55,6 @@ func newDeleteCmd(cmdContext *base.CmdContext, sslType *SSLTypeCmd) *cobra.Comma
 
 			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
 
-			id := args[0]
 			err := sslType.managers.deleteMgr.Delete(ctx, scClient, id)
 			if err != nil {
 				return err
@@ -49,5 +63,8 @@ func newDeleteCmd(cmdContext *base.CmdContext, sslType *SSLTypeCmd) *cobra.Comma
 			return nil
 		},
 	}
+
+	bulkOpts.AddFlags(cmd)
+
 	return cmd
 }
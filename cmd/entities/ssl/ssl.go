@@ -107,6 +107,16 @@ func newSSLTypeCmd(cmdContext *base.CmdContext, sslTypeCmd SSLTypeCmd) *cobra.Co
 		sslCmd.AddCommand(cmdFunc(cmdContext))
 	}
 
+	collection := func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.SSLCertificate], error) {
+		return client.SSLCertificates.Collection().SetParam("type", sslTypeCmd.typeFlag), nil
+	}
+	base.SetIDCompletion(sslCmd, base.CompleteIDs(cmdContext, collection,
+		func(i serverscom.SSLCertificate) cobra.Completion { return cobra.CompletionWithDesc(i.ID, i.Name) },
+	))
+	base.SetIDResolution(sslCmd, cmdContext, base.NewIDResolver("SSL certificate", collection,
+		func(i serverscom.SSLCertificate) (string, string) { return i.ID, i.Name },
+	))
+
 	return sslCmd
 }
 
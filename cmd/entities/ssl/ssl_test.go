@@ -687,7 +687,7 @@ func TestDeleteCustomSSLKeysCmd(t *testing.T) {
 			testCmdContext := testutils.NewTestCmdContext(scClient)
 			sslCmd := NewCmd(testCmdContext)
 
-			args := []string{"ssl", "custom", "delete", tc.id}
+			args := []string{"ssl", "custom", "delete", tc.id, "--yes"}
 			builder := testutils.NewTestCommandBuilder().
 				WithCommand(sslCmd).
 				WithArgs(args)
@@ -745,7 +745,7 @@ func TestDeleteLeSSLKeysCmd(t *testing.T) {
 			testCmdContext := testutils.NewTestCmdContext(scClient)
 			sslCmd := NewCmd(testCmdContext)
 
-			args := []string{"ssl", "le", "delete", tc.id}
+			args := []string{"ssl", "le", "delete", tc.id, "--yes"}
 			builder := testutils.NewTestCommandBuilder().
 				WithCommand(sslCmd).
 				WithArgs(args)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
 	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/spf13/cobra"
 )
@@ -53,5 +54,12 @@ func newGetCmd(cmdContext *base.CmdContext) *cobra.Command {
 	_ = cmd.MarkFlagRequired("server-model-id")
 	_ = cmd.MarkFlagRequired("uplink-model-id")
 
+	cmd.ValidArgsFunction = base.CompleteIDs(cmdContext,
+		func(cmd *cobra.Command, client *serverscom.Client) (serverscom.Collection[serverscom.BandwidthOption], error) {
+			return client.Locations.BandwidthOptions(locationID, serverModelID, uplinkModelID), nil
+		},
+		func(b serverscom.BandwidthOption) cobra.Completion { return base.Int64Completion(b.ID, b.Name) },
+	)
+
 	return cmd
 }
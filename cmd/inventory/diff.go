@@ -0,0 +1,49 @@
+package inventory
+
+import (
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/internal/inventory"
+	"github.com/spf13/cobra"
+)
+
+// changesExitCode is an exit code used when snapshots differ
+const changesExitCode = 2
+
+func newDiffCmd(cmdContext *base.CmdContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <before> <after>",
+		Short: "Show differences between two snapshots",
+		Long:  `Show resources added, removed and changed between two snapshots made by inventory export. Snapshots can be JSON or YAML, '-' reads one of them from stdin. Exits with code 2 if there are differences.`,
+		PersistentPreRunE: base.CombinePreRunE(
+			base.CheckOutputs(cmdContext, "text", "json", "yaml"),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			before, err := inventory.ReadFile(args[0], cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			after, err := inventory.ReadFile(args[1], cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			changes := inventory.Diff(before, after)
+
+			formatter := cmdContext.GetOrCreateFormatter(cmd)
+			if err := formatter.FormatInventoryDiff(changes); err != nil {
+				return err
+			}
+
+			if len(changes) > 0 {
+				// the diff is already printed, exit code is the only signal
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+				return &base.ExitCodeError{Code: changesExitCode, Message: "snapshots differ"}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,48 @@
+package inventory
+
+import (
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/internal/inventory"
+	"github.com/spf13/cobra"
+)
+
+func newExportCmd(cmdContext *base.CmdContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a snapshot of all resources",
+		Long: `Export a versioned snapshot of all resources of the account: hosts of every type with their networks and PTR records, L2 segments, network pools, load balancers, SSL certificates, cloud instances, volumes and backups, RBS volumes, K8s clusters and SSH keys.
+The snapshot is written as JSON, or as YAML with --output yaml.`,
+		PersistentPreRunE: base.CombinePreRunE(
+			base.CheckOutputs(cmdContext, "text", "json", "yaml"),
+			base.CheckEmptyContexts(cmdContext),
+		),
+		Args: base.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager := cmdContext.GetManager()
+			ctx, cancel := base.SetupContext(cmd, manager)
+			defer cancel()
+
+			base.SetupProxy(cmd, manager)
+			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
+
+			parallel, err := manager.GetResolvedIntValue(cmd, "parallel")
+			if err != nil {
+				return err
+			}
+
+			snapshot, err := inventory.Export(ctx, scClient, inventory.ExportOptions{Parallel: parallel})
+			if err != nil {
+				return err
+			}
+
+			formatter := cmdContext.GetOrCreateFormatter(cmd)
+			// a snapshot has no table view
+			if formatter.GetOutput() == "text" {
+				formatter.SetOutput("json")
+			}
+			return formatter.Format(snapshot)
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,23 @@
+package inventory
+
+import (
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "Export and compare account inventory",
+		Long:  `Export snapshots of all resources of the account and compare them to find added, removed and changed resources`,
+		Args:  base.NoArgs,
+		Run:   base.UsageRun,
+	}
+
+	cmd.AddCommand(
+		newExportCmd(cmdContext),
+		newDiffCmd(cmdContext),
+	)
+
+	return cmd
+}
@@ -0,0 +1,260 @@
+package inventory
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/cmd/testutils"
+	"github.com/serverscom/srvctl/internal/inventory"
+	"github.com/serverscom/srvctl/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	fixtureBasePath = filepath.Join("..", "..", "testdata", "inventory")
+	fixedTime       = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	testHost        = serverscom.DedicatedServer{
+		ID:           "h1",
+		Title:        "web-01",
+		Type:         "dedicated_server",
+		Status:       "active",
+		LocationID:   1,
+		LocationCode: "AMS1",
+		Created:      fixedTime,
+		Updated:      fixedTime,
+	}
+	testNetwork = serverscom.Network{
+		ID:      "n1",
+		Status:  "active",
+		Family:  "ipv4",
+		Created: fixedTime,
+		Updated: fixedTime,
+	}
+	testPTR = serverscom.PTRRecord{
+		ID:       "p1",
+		IP:       "192.0.2.1",
+		Domain:   "web-01.example.com",
+		Priority: 10,
+		TTL:      60,
+	}
+	testSegment = serverscom.L2Segment{
+		ID:      "s1",
+		Name:    "private",
+		Status:  "active",
+		Created: fixedTime,
+		Updated: fixedTime,
+	}
+	testKeys = []serverscom.SSHKey{
+		{Name: "deploy", Fingerprint: "bb:cc", Created: fixedTime, Updated: fixedTime},
+		{Name: "admin", Fingerprint: "aa:bb", Created: fixedTime, Updated: fixedTime},
+	}
+)
+
+// collectionOf returns a mock collection of items
+func collectionOf[T any](ctrl *gomock.Controller, items []T, err error) *mocks.MockCollection[T] {
+	collection := mocks.NewMockCollection[T](ctrl)
+	collection.EXPECT().Collect(gomock.Any()).Return(items, err).AnyTimes()
+	return collection
+}
+
+// newTestClient returns a client listing a host with a network and a PTR record,
+// an L2 segment and SSH keys, listing SSH keys fails with keysErr
+func newTestClient(ctrl *gomock.Controller, keysErr error) *serverscom.Client {
+	hosts := mocks.NewMockHostsService(ctrl)
+	hosts.EXPECT().ListDedicatedServers().Return(collectionOf(ctrl, []serverscom.DedicatedServer{testHost}, nil)).AnyTimes()
+	hosts.EXPECT().ListKubernetesBaremetalNodes().Return(collectionOf[serverscom.KubernetesBaremetalNode](ctrl, nil, nil)).AnyTimes()
+	hosts.EXPECT().ListSBMServers().Return(collectionOf[serverscom.SBMServer](ctrl, nil, nil)).AnyTimes()
+	hosts.EXPECT().DedicatedServerNetworks("h1").Return(collectionOf(ctrl, []serverscom.Network{testNetwork}, nil)).AnyTimes()
+	hosts.EXPECT().DedicatedServerPTRRecords("h1").Return(collectionOf(ctrl, []serverscom.PTRRecord{testPTR}, nil)).AnyTimes()
+
+	l2Segments := mocks.NewMockL2SegmentsService(ctrl)
+	l2Segments.EXPECT().Collection().Return(collectionOf(ctrl, []serverscom.L2Segment{testSegment}, nil)).AnyTimes()
+	networkPools := mocks.NewMockNetworkPoolsService(ctrl)
+	networkPools.EXPECT().Collection().Return(collectionOf[serverscom.NetworkPool](ctrl, nil, nil)).AnyTimes()
+	loadBalancers := mocks.NewMockLoadBalancersService(ctrl)
+	loadBalancers.EXPECT().Collection().Return(collectionOf[serverscom.LoadBalancer](ctrl, nil, nil)).AnyTimes()
+	ssl := mocks.NewMockSSLCertificatesService(ctrl)
+	ssl.EXPECT().Collection().Return(collectionOf[serverscom.SSLCertificate](ctrl, nil, nil)).AnyTimes()
+	instances := mocks.NewMockCloudComputingInstancesService(ctrl)
+	instances.EXPECT().Collection().Return(collectionOf[serverscom.CloudComputingInstance](ctrl, nil, nil)).AnyTimes()
+	volumes := mocks.NewMockCloudBlockStorageVolumesService(ctrl)
+	volumes.EXPECT().Collection().Return(collectionOf[serverscom.CloudBlockStorageVolume](ctrl, nil, nil)).AnyTimes()
+	backups := mocks.NewMockCloudBlockStorageBackupsService(ctrl)
+	backups.EXPECT().Collection().Return(collectionOf[serverscom.CloudBlockStorageBackup](ctrl, nil, nil)).AnyTimes()
+	rbs := mocks.NewMockRemoteBlockStorageVolumesService(ctrl)
+	rbs.EXPECT().Collection().Return(collectionOf[serverscom.RemoteBlockStorageVolume](ctrl, nil, nil)).AnyTimes()
+	k8s := mocks.NewMockKubernetesClustersService(ctrl)
+	k8s.EXPECT().Collection().Return(collectionOf[serverscom.KubernetesCluster](ctrl, nil, nil)).AnyTimes()
+	sshKeys := mocks.NewMockSSHKeysService(ctrl)
+	sshKeys.EXPECT().Collection().Return(collectionOf(ctrl, testKeys, keysErr)).AnyTimes()
+
+	scClient := serverscom.NewClientWithEndpoint("", "")
+	scClient.Hosts = hosts
+	scClient.L2Segments = l2Segments
+	scClient.NetworkPools = networkPools
+	scClient.LoadBalancers = loadBalancers
+	scClient.SSLCertificates = ssl
+	scClient.CloudComputingInstances = instances
+	scClient.CloudBlockStorageVolumes = volumes
+	scClient.CloudBlockStorageBackups = backups
+	scClient.RemoteBlockStorageVolumes = rbs
+	scClient.KubernetesClusters = k8s
+	scClient.SSHKeys = sshKeys
+	return scClient
+}
+
+func TestExportCmd(t *testing.T) {
+	testCases := []struct {
+		name        string
+		args        []string
+		keysErr     error
+		expectError bool
+	}{
+		{
+			name: "export json",
+		},
+		{
+			name: "export yaml",
+			args: []string{"--output", "yaml"},
+		},
+		{
+			name: "export in parallel",
+			args: []string{"--parallel", "4"},
+		},
+		{
+			name:        "export with list error",
+			args:        []string{"--parallel", "4"},
+			keysErr:     errors.New("some error"),
+			expectError: true,
+		},
+		{
+			name:        "export with unsupported output",
+			args:        []string{"--output", "csv"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			testCmdContext := testutils.NewTestCmdContext(newTestClient(mockCtrl, tc.keysErr))
+			inventoryCmd := NewCmd(testCmdContext)
+
+			args := append([]string{"inventory", "export"}, tc.args...)
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(inventoryCmd).
+				WithArgs(args)
+
+			cmd := builder.Build()
+
+			err := cmd.Execute()
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(BeNil())
+
+			// the creation time is the only value which differs between exports
+			snapshot, err := inventory.Parse([]byte(builder.GetOutput()), "output")
+			g.Expect(err).To(BeNil())
+			g.Expect(snapshot.CreatedAt).NotTo(BeZero())
+			snapshot.CreatedAt = fixedTime
+
+			data, err := json.Marshal(snapshot)
+			g.Expect(err).To(BeNil())
+			g.Expect(data).To(MatchJSON(testutils.ReadFixture(filepath.Join(fixtureBasePath, "export.json"))))
+		})
+	}
+}
+
+func TestDiffCmd(t *testing.T) {
+	testCases := []struct {
+		name             string
+		output           string
+		args             []string
+		input            string
+		expectedOutput   []byte
+		expectedExitCode int
+		expectError      bool
+	}{
+		{
+			name:             "diff json and yaml snapshots",
+			args:             []string{filepath.Join(fixtureBasePath, "before.json"), filepath.Join(fixtureBasePath, "after.yaml")},
+			expectedOutput:   testutils.ReadFixture(filepath.Join(fixtureBasePath, "diff.txt")),
+			expectedExitCode: changesExitCode,
+		},
+		{
+			name:             "diff in json",
+			output:           "json",
+			args:             []string{filepath.Join(fixtureBasePath, "before.json"), filepath.Join(fixtureBasePath, "after.yaml")},
+			expectedOutput:   testutils.ReadFixture(filepath.Join(fixtureBasePath, "diff.json")),
+			expectedExitCode: changesExitCode,
+		},
+		{
+			name:           "diff with stdin",
+			args:           []string{filepath.Join(fixtureBasePath, "before.json"), "-"},
+			input:          string(testutils.ReadFixture(filepath.Join(fixtureBasePath, "before.json"))),
+			expectedOutput: []byte("Inventory: 0 added, 0 removed, 0 changed.\n"),
+		},
+		{
+			name:        "diff with missing snapshot",
+			args:        []string{filepath.Join(fixtureBasePath, "before.json"), filepath.Join(fixtureBasePath, "missing.json")},
+			expectError: true,
+		},
+		{
+			name:        "diff with one snapshot",
+			args:        []string{filepath.Join(fixtureBasePath, "before.json")},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			testCmdContext := testutils.NewTestCmdContext(serverscom.NewClientWithEndpoint("", ""))
+			inventoryCmd := NewCmd(testCmdContext)
+
+			args := append([]string{"inventory", "diff"}, tc.args...)
+			if tc.output != "" {
+				args = append(args, "--output", tc.output)
+			}
+
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(inventoryCmd).
+				WithInput(strings.NewReader(tc.input)).
+				WithArgs(args)
+
+			cmd := builder.Build()
+
+			err := cmd.Execute()
+
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			if tc.expectedExitCode != 0 {
+				var exitErr *base.ExitCodeError
+				g.Expect(errors.As(err, &exitErr)).To(BeTrue())
+				g.Expect(exitErr.Code).To(Equal(tc.expectedExitCode))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+			if tc.output == "json" {
+				g.Expect(builder.GetOutput()).To(MatchJSON(tc.expectedOutput))
+			} else {
+				g.Expect(builder.GetOutput()).To(BeEquivalentTo(string(tc.expectedOutput)))
+			}
+		})
+	}
+}
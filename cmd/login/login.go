@@ -20,18 +20,30 @@ import (
 	"golang.org/x/term"
 )
 
+// tokenStores are token stores of the login command
+var tokenStores = []string{config.TokenStoreConfig, config.TokenStoreFile, config.TokenStoreCommand}
+
 func NewCmd(cmdContext *base.CmdContext, clientFactory client.ClientFactory) *cobra.Command {
 	var (
-		force      bool
-		endpoint   string
-		setDefault bool
+		force        bool
+		endpoint     string
+		setDefault   bool
+		store        string
+		tokenFile    string
+		tokenCommand string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "login <context-name>",
 		Short: "Login to servers.com API",
 		Long: `Login to servers.com API via token and save the credentials in a named context.
-Example: srvctl login context-name`,
+Example: srvctl login context-name
+
+The token is stored in a file readable by the current user only by default,
+tokens/<context-name> next to the config. Use --store=config to keep it in the
+config, e.g. when the config is encrypted, or --store=command to get it from an
+external helper every time it's needed, e.g.:
+  srvctl login context-name --store=command --token-command 'pass show servers.com'`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// login only if SC_TOKEN env is not set
@@ -39,7 +51,15 @@ Example: srvctl login context-name`,
 				return errors.New("SC_TOKEN env is set. Please unset it before using login command")
 			}
 
-			if !term.IsTerminal(int(os.Stdout.Fd())) {
+			if err := config.ValidateTokenStore(store, tokenStores...); err != nil {
+				return err
+			}
+			if store == config.TokenStoreCommand && tokenCommand == "" {
+				return errors.New("--token-command is required with --store=command")
+			}
+
+			// the token is entered unless it's printed by the helper
+			if store != config.TokenStoreCommand && !term.IsTerminal(int(os.Stdout.Fd())) {
 				return errors.New("TTY required to enter the token")
 			}
 			manager := cmdContext.GetManager()
@@ -78,9 +98,32 @@ Example: srvctl login context-name`,
 				return err
 			}
 
-			token, err := readSecureInput(cmd.InOrStdin())
-			if err != nil {
-				return fmt.Errorf("failed to read token: %w", err)
+			if store == config.TokenStoreFile && tokenFile == "" {
+				tokenFile = manager.GetTokenFilePath(contextName)
+				if tokenFile == "" {
+					return errors.New("--token-file is required with --store=file when config isn't stored in a file")
+				}
+			}
+
+			cfgCtx := config.Context{
+				Name:         contextName,
+				Endpoint:     endpoint,
+				TokenCommand: tokenCommand,
+				Config:       make(map[string]any),
+			}
+
+			var token string
+			var err error
+			if store == config.TokenStoreCommand {
+				token, err = cfgCtx.ResolveToken()
+				if err != nil {
+					return err
+				}
+			} else {
+				token, err = readSecureInput(cmd.InOrStdin())
+				if err != nil {
+					return fmt.Errorf("failed to read token: %w", err)
+				}
 			}
 
 			ctx, cancel := base.SetupContext(cmd, manager)
@@ -93,11 +136,14 @@ Example: srvctl login context-name`,
 				return fmt.Errorf("failed to verify credentials: %w", err)
 			}
 
-			cfgCtx := config.Context{
-				Name:     contextName,
-				Endpoint: endpoint,
-				Token:    token,
-				Config:   make(map[string]any),
+			switch store {
+			case config.TokenStoreConfig:
+				cfgCtx.Token = token
+			case config.TokenStoreFile:
+				if err := config.WriteTokenFile(tokenFile, token); err != nil {
+					return fmt.Errorf("failed to save token: %w", err)
+				}
+				cfgCtx.TokenFile = tokenFile
 			}
 
 			if err := manager.SetContext(cfgCtx); err != nil {
@@ -126,6 +172,9 @@ Example: srvctl login context-name`,
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force override existing context")
 	cmd.Flags().StringVar(&endpoint, "endpoint", base.ENDPOINT, "API endpoint")
 	cmd.Flags().BoolVarP(&setDefault, "default", "d", true, "Set as default context")
+	cmd.Flags().StringVar(&store, "store", config.DefaultTokenStore, "Where to store the token (file/config/command)")
+	cmd.Flags().StringVar(&tokenFile, "token-file", "", "Token file path for --store=file, defaults to tokens/<context-name> next to the config")
+	cmd.Flags().StringVar(&tokenCommand, "token-command", "", "Command printing the token for --store=command")
 
 	return cmd
 }
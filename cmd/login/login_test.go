@@ -10,8 +10,10 @@ import (
 	"github.com/creack/pty"
 	. "github.com/onsi/gomega"
 	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/cmd/base"
 	"github.com/serverscom/srvctl/cmd/testutils"
 	"github.com/serverscom/srvctl/internal/client"
+	"github.com/serverscom/srvctl/internal/config"
 	"github.com/serverscom/srvctl/internal/mocks"
 	"go.uber.org/mock/gomock"
 )
@@ -21,14 +23,19 @@ var (
 )
 
 func TestLoginCmd(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens", "file-context")
+	configDir := t.TempDir()
+
 	testCases := []struct {
-		name           string
-		input          io.Reader
-		args           []string
-		configureMock  func(*mocks.MockCollection[serverscom.Host])
-		expectedOutput []byte
-		expectError    bool
-		tty            bool
+		name            string
+		input           io.Reader
+		args            []string
+		configureMock   func(*mocks.MockCollection[serverscom.Host])
+		expectedOutput  []byte
+		expectedContext *config.Context
+		expectedToken   string
+		expectError     bool
+		tty             bool
 	}{
 		{
 			name:        "login with empty token",
@@ -46,7 +53,14 @@ func TestLoginCmd(t *testing.T) {
 					List(gomock.Any()).
 					Return([]serverscom.Host{}, nil)
 			},
-			tty: true,
+			expectedContext: &config.Context{
+				Name:      "test-context",
+				Endpoint:  base.ENDPOINT,
+				TokenFile: filepath.Join(configDir, "tokens", "test-context"),
+				Config:    map[string]any{},
+			},
+			expectedToken: "token",
+			tty:           true,
 		},
 		{
 			name:           "login with force",
@@ -65,6 +79,77 @@ func TestLoginCmd(t *testing.T) {
 			args:        []string{"_invalid"},
 			expectError: true,
 		},
+		{
+			name:  "login with config store",
+			args:  []string{"config-context", "--store", "config"},
+			input: strings.NewReader("config-token\n"),
+			configureMock: func(mock *mocks.MockCollection[serverscom.Host]) {
+				mock.EXPECT().
+					List(gomock.Any()).
+					Return([]serverscom.Host{}, nil)
+			},
+			expectedOutput: []byte("Successfully logged in with context \"config-context\"\nContext \"config-context\" set as default\n"),
+			expectedContext: &config.Context{
+				Name:     "config-context",
+				Endpoint: base.ENDPOINT,
+				Token:    "config-token",
+				Config:   map[string]any{},
+			},
+			expectedToken: "config-token",
+			tty:           true,
+		},
+		{
+			name:  "login with token file",
+			args:  []string{"file-context", "--store", "file", "--token-file", tokenFile},
+			input: strings.NewReader("file-token\n"),
+			configureMock: func(mock *mocks.MockCollection[serverscom.Host]) {
+				mock.EXPECT().
+					List(gomock.Any()).
+					Return([]serverscom.Host{}, nil)
+			},
+			expectedOutput: []byte("Successfully logged in with context \"file-context\"\nContext \"file-context\" set as default\n"),
+			expectedContext: &config.Context{
+				Name:      "file-context",
+				Endpoint:  base.ENDPOINT,
+				TokenFile: tokenFile,
+				Config:    map[string]any{},
+			},
+			expectedToken: "file-token",
+			tty:           true,
+		},
+		{
+			name: "login with token command without TTY",
+			args: []string{"command-context", "--store", "command", "--token-command", "echo command-token"},
+			configureMock: func(mock *mocks.MockCollection[serverscom.Host]) {
+				mock.EXPECT().
+					List(gomock.Any()).
+					Return([]serverscom.Host{}, nil)
+			},
+			expectedOutput: []byte("Successfully logged in with context \"command-context\"\nContext \"command-context\" set as default\n"),
+			expectedContext: &config.Context{
+				Name:         "command-context",
+				Endpoint:     base.ENDPOINT,
+				TokenCommand: "echo command-token",
+				Config:       map[string]any{},
+			},
+			expectedToken: "command-token",
+		},
+		{
+			name:        "login with failing token command",
+			args:        []string{"command-context", "--store", "command", "--token-command", "exit 1"},
+			expectError: true,
+		},
+		{
+			name:        "login with token command store without command",
+			args:        []string{"command-context", "--store", "command"},
+			expectError: true,
+		},
+		{
+			name:        "login with invalid store",
+			args:        []string{"test-context", "--store", "keyring"},
+			expectError: true,
+			tty:         true,
+		},
 		{
 			name:        "no TTY",
 			args:        []string{"notty"},
@@ -92,7 +177,15 @@ func TestLoginCmd(t *testing.T) {
 
 	scClient := serverscom.NewClientWithEndpoint("", "")
 	scClient.Hosts = hostsServiceHandler
-	testCmdContext := testutils.NewTestCmdContext(scClient)
+	configPath := filepath.Join(configDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("defaultContext: test\ncontexts:\n  - name: test\n"), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	manager, err := config.NewManager(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	testCmdContext := base.NewCmdContext(manager, client.NewWithClient(scClient))
 	testClientFactory := &client.TestClientFactory{
 		TestClient: client.NewWithClient(scClient),
 	}
@@ -139,6 +232,17 @@ func TestLoginCmd(t *testing.T) {
 				g.Expect(err).To(BeNil())
 				g.Expect(builder.GetOutput()).To(BeEquivalentTo(string(tc.expectedOutput)))
 			}
+
+			if tc.expectedContext != nil {
+				manager := testCmdContext.GetManager()
+				ctx, err := manager.GetContext(tc.expectedContext.Name)
+				g.Expect(err).To(BeNil())
+				g.Expect(*ctx).To(Equal(*tc.expectedContext))
+
+				token, err := manager.GetToken(tc.expectedContext.Name)
+				g.Expect(err).To(BeNil())
+				g.Expect(token).To(Equal(tc.expectedToken))
+			}
 		})
 	}
 }
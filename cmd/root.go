@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"github.com/serverscom/srvctl/cmd/apply"
 	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/cmd/cache"
 	"github.com/serverscom/srvctl/cmd/config"
 	"github.com/serverscom/srvctl/cmd/context"
+	"github.com/serverscom/srvctl/cmd/diff"
 	"github.com/serverscom/srvctl/cmd/entities/account"
 	cloudbackups "github.com/serverscom/srvctl/cmd/entities/cloud-backups"
 	cloudinstances "github.com/serverscom/srvctl/cmd/entities/cloud-instances"
@@ -30,7 +33,10 @@ import (
 	"github.com/serverscom/srvctl/cmd/entities/ssl"
 	"github.com/serverscom/srvctl/cmd/entities/uplinkbandwidths"
 	"github.com/serverscom/srvctl/cmd/entities/uplinkmodels"
+	"github.com/serverscom/srvctl/cmd/inventory"
 	"github.com/serverscom/srvctl/cmd/login"
+	"github.com/serverscom/srvctl/cmd/search"
+	"github.com/serverscom/srvctl/cmd/wait"
 	"github.com/serverscom/srvctl/internal/client"
 	"github.com/spf13/cobra"
 )
@@ -77,8 +83,16 @@ func NewRootCmd(version string) *cobra.Command {
 		login.NewCmd(cmdContext, clientFactory),
 		context.NewCmd(cmdContext),
 		config.NewCmd(cmdContext),
+		cache.NewCmd(cmdContext),
 	)
 
+	// configuration commands don't call the API, so tokens aren't resolved for them
+	for _, c := range cmd.Commands() {
+		if c.GroupID == groupConfig {
+			base.SetLocal(c)
+		}
+	}
+
 	// Resource commands
 	addGroupedCommands(cmd, groupResources,
 		sshkeys.NewCmd(cmdContext),
@@ -112,6 +126,18 @@ func NewRootCmd(version string) *cobra.Command {
 		metrics.NewCmd(cmdContext),
 	)
 
+	// Other commands
+	addGroupedCommands(cmd, groupOther,
+		apply.NewCmd(cmdContext),
+		diff.NewCmd(cmdContext),
+		wait.NewCmd(cmdContext),
+		inventory.NewCmd(cmdContext),
+		search.NewCmd(cmdContext),
+	)
+
+	base.SetGetWatch(cmd, cmdContext)
+	base.RegisterFlagCompletions(cmd, cmdContext)
+
 	cmd.SetHelpCommandGroupID(groupOther)
 	cmd.SetCompletionCommandGroupID(groupOther)
 
@@ -0,0 +1,63 @@
+package search
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/internal/output/entities"
+	"github.com/serverscom/srvctl/internal/search"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
+	searchResultEntity, err := entities.Registry.GetEntityFromValue(search.Result{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	entitiesMap := make(map[string]entities.EntityInterface)
+	entitiesMap["search"] = searchResultEntity
+
+	var (
+		kinds    []string
+		nameOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "search <term>",
+		Short: "Search resources by name, IP address or label",
+		Long: `Search hosts, cloud instances, load balancers, L2 segments, network pools, SSL certificates and RBS volumes by a name fragment, an IP address or a label at the same time.
+A term matches names and IP addresses by a case-insensitive substring, addresses within network pools, and labels by 'key=value' or a substring of a key or a value.
+All resources are listed to match them locally. Use --name to match names only, hosts, load balancers, network pools and SSL certificates are filtered by the API then.`,
+		PersistentPreRunE: base.CombinePreRunE(
+			base.CheckFormatterFlags(cmdContext, entitiesMap),
+			base.CheckEmptyContexts(cmdContext),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manager := cmdContext.GetManager()
+			ctx, cancel := base.SetupContext(cmd, manager)
+			defer cancel()
+
+			base.SetupProxy(cmd, manager)
+			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
+
+			results, err := search.Search(ctx, scClient, args[0], search.Options{Kinds: kinds, Name: nameOnly})
+			if err != nil {
+				return err
+			}
+
+			formatter := cmdContext.GetOrCreateFormatter(cmd)
+			return formatter.Format(results)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&kinds, "kind", nil, fmt.Sprintf("search only these kinds: %s", strings.Join(search.KindNames(), ", ")))
+	cmd.Flags().BoolVar(&nameOnly, "name", false, "match the term against names only, filtering kinds which support it by the API")
+	_ = cmd.RegisterFlagCompletionFunc("kind", cobra.FixedCompletions(search.KindNames(), cobra.ShellCompDirectiveNoFileComp))
+
+	base.AddFormatFlags(cmd)
+
+	return cmd
+}
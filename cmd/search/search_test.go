@@ -0,0 +1,201 @@
+package search
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/cmd/testutils"
+	"github.com/serverscom/srvctl/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	fixtureBasePath = filepath.Join("..", "..", "testdata", "entities", "search")
+	publicIP        = "192.0.2.10"
+	poolTitle       = "private"
+	testHost        = serverscom.Host{
+		ID:                "h1",
+		Type:              "dedicated_server",
+		Title:             "web-01",
+		LocationCode:      "AMS1",
+		PublicIPv4Address: &publicIP,
+		Labels:            map[string]string{"env": "prod"},
+	}
+	testInstances = []serverscom.CloudComputingInstance{
+		{ID: "i1", Name: "web-vm", RegionCode: "AMS1", Labels: map[string]string{"env": "stage"}},
+		{ID: "i2", Name: "db-vm", RegionCode: "AMS1", PublicIPv4Address: &publicIP, Labels: map[string]string{"env": "prod"}},
+	}
+	testLB = serverscom.LoadBalancer{
+		ID:                "lb1",
+		Name:              "web-lb",
+		LocationCode:      "WDC1",
+		ExternalAddresses: []string{"198.51.100.1"},
+	}
+	testSegments = []serverscom.L2Segment{
+		{ID: "s1", Name: "web-backend", LocationGroupCode: "EU"},
+		{ID: "s2", Name: "storage", LocationGroupCode: "EU"},
+	}
+	testPool = serverscom.NetworkPool{
+		ID:            "np1",
+		Title:         &poolTitle,
+		CIDR:          "192.0.2.0/24",
+		LocationCodes: []string{"AMS1", "AMS2"},
+	}
+	testCert = serverscom.SSLCertificate{
+		ID:          "c1",
+		Name:        "wildcard",
+		DomainNames: []string{"*.web.example.com"},
+	}
+	testVolumes = []serverscom.RemoteBlockStorageVolume{
+		{ID: "v1", Name: "backups", RegionCode: "AMS1"},
+	}
+)
+
+// collectionOf returns a mock collection of items, which expects search_pattern
+// to be set to pattern unless it's empty
+func collectionOf[T any](ctrl *gomock.Controller, pattern string, items []T, err error) *mocks.MockCollection[T] {
+	collection := mocks.NewMockCollection[T](ctrl)
+	if pattern != "" {
+		collection.EXPECT().SetParam("search_pattern", pattern).Return(collection)
+	}
+	collection.EXPECT().Collect(gomock.Any()).Return(items, err).AnyTimes()
+	return collection
+}
+
+// newTestClient returns a client with resources of all searched kinds, kinds which
+// support search_pattern return their resources only when searched by pattern
+func newTestClient(ctrl *gomock.Controller, pattern string, instancesErr error) *serverscom.Client {
+	hosts := mocks.NewMockHostsService(ctrl)
+	hosts.EXPECT().Collection().Return(collectionOf(ctrl, pattern, []serverscom.Host{testHost}, nil)).AnyTimes()
+	instances := mocks.NewMockCloudComputingInstancesService(ctrl)
+	instances.EXPECT().Collection().Return(collectionOf(ctrl, "", testInstances, instancesErr)).AnyTimes()
+	loadBalancers := mocks.NewMockLoadBalancersService(ctrl)
+	loadBalancers.EXPECT().Collection().Return(collectionOf(ctrl, pattern, []serverscom.LoadBalancer{testLB}, nil)).AnyTimes()
+	l2Segments := mocks.NewMockL2SegmentsService(ctrl)
+	l2Segments.EXPECT().Collection().Return(collectionOf(ctrl, "", testSegments, nil)).AnyTimes()
+	networkPools := mocks.NewMockNetworkPoolsService(ctrl)
+	networkPools.EXPECT().Collection().Return(collectionOf(ctrl, pattern, []serverscom.NetworkPool{testPool}, nil)).AnyTimes()
+	ssl := mocks.NewMockSSLCertificatesService(ctrl)
+	ssl.EXPECT().Collection().Return(collectionOf(ctrl, pattern, []serverscom.SSLCertificate{testCert}, nil)).AnyTimes()
+	rbs := mocks.NewMockRemoteBlockStorageVolumesService(ctrl)
+	rbs.EXPECT().Collection().Return(collectionOf(ctrl, "", testVolumes, nil)).AnyTimes()
+
+	scClient := serverscom.NewClientWithEndpoint("", "")
+	scClient.Hosts = hosts
+	scClient.CloudComputingInstances = instances
+	scClient.LoadBalancers = loadBalancers
+	scClient.L2Segments = l2Segments
+	scClient.NetworkPools = networkPools
+	scClient.SSLCertificates = ssl
+	scClient.RemoteBlockStorageVolumes = rbs
+	return scClient
+}
+
+func TestSearchCmd(t *testing.T) {
+	testCases := []struct {
+		name           string
+		args           []string
+		output         string
+		pattern        string
+		instancesErr   error
+		expectedOutput []byte
+		expectError    bool
+	}{
+		{
+			name:           "search by term",
+			args:           []string{"web"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "term.txt")),
+		},
+		{
+			name:           "search by name",
+			args:           []string{"web", "--name"},
+			pattern:        "web",
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "name.txt")),
+		},
+		{
+			name:           "search by name in json",
+			args:           []string{"web", "--name"},
+			output:         "json",
+			pattern:        "web",
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "name.json")),
+		},
+		{
+			name:           "search by ip",
+			args:           []string{"192.0.2.10"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "ip.txt")),
+		},
+		{
+			name:           "search by label",
+			args:           []string{"env=prod"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "label.txt")),
+		},
+		{
+			name:           "search by label value",
+			args:           []string{"prod"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "label.txt")),
+		},
+		{
+			name:           "search in kinds",
+			args:           []string{"web", "--kind", "l2-segments,rbs"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "kinds.txt")),
+		},
+		{
+			name:           "search without results",
+			args:           []string{"missing", "--kind", "rbs"},
+			expectedOutput: []byte("Kind   ID   Name   Location   Matched   Value\n"),
+		},
+		{
+			name:        "search with unknown kind",
+			args:        []string{"web", "--kind", "unknown"},
+			expectError: true,
+		},
+		{
+			name:         "search with list error",
+			args:         []string{"192.0.2.10"},
+			instancesErr: errors.New("some error"),
+			expectError:  true,
+		},
+		{
+			name:        "search without term",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			testCmdContext := testutils.NewTestCmdContext(newTestClient(mockCtrl, tc.pattern, tc.instancesErr))
+			searchCmd := NewCmd(testCmdContext)
+
+			args := append([]string{"search"}, tc.args...)
+			if tc.output != "" {
+				args = append(args, "--output", tc.output)
+			}
+
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(searchCmd).
+				WithArgs(args)
+
+			cmd := builder.Build()
+
+			err := cmd.Execute()
+
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).To(BeNil())
+			if tc.output == "json" {
+				g.Expect(builder.GetOutput()).To(MatchJSON(tc.expectedOutput))
+			} else {
+				g.Expect(builder.GetOutput()).To(BeEquivalentTo(string(tc.expectedOutput)))
+			}
+		})
+	}
+}
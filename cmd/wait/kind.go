@@ -0,0 +1,119 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/internal/output/entities"
+	"github.com/serverscom/srvctl/internal/output/utils"
+	"github.com/spf13/cobra"
+)
+
+const deleteCondition = "delete"
+
+// condition represents parsed --for flag value
+type condition struct {
+	field *entities.Field
+	value string
+}
+
+// parseCondition parses 'field=value' or 'delete' condition
+func parseCondition(s string, entity entities.EntityInterface) (*condition, error) {
+	if s == deleteCondition {
+		return &condition{}, nil
+	}
+
+	name, value, ok := strings.Cut(s, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("invalid condition %q, expected 'field=value' or 'delete'", s)
+	}
+
+	field, err := entity.GetField(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &condition{field: field, value: strings.TrimSpace(value)}, nil
+}
+
+// isDelete reports whether condition waits for resource deletion
+func (c *condition) isDelete() bool {
+	return c.field == nil
+}
+
+func newWaitKindCmd(cmdContext *base.CmdContext, kind ResourceKind, entity entities.EntityInterface) *cobra.Command {
+	var forFlag string
+	waitOpts := &base.WaitOptions{}
+
+	cmd := &cobra.Command{
+		Use:   kind.use + " <id>",
+		Short: fmt.Sprintf("Wait for a condition on %s", kind.use),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cond, err := parseCondition(forFlag, entity)
+			if err != nil {
+				return err
+			}
+
+			manager := cmdContext.GetManager()
+			base.SetupProxy(cmd, manager)
+			scClient := cmdContext.GetClient().SetVerbose(manager.GetVerbose(cmd)).GetScClient()
+
+			id := args[0]
+			var (
+				item  any
+				state string
+			)
+			err = base.Poll(context.Background(), waitOpts, func(ctx context.Context) (bool, error) {
+				v, err := kind.getter.Get(ctx, scClient, id)
+				if err != nil {
+					var notFound *serverscom.NotFoundError
+					if cond.isDelete() && errors.As(err, &notFound) {
+						return true, nil
+					}
+					return false, err
+				}
+				item = v
+
+				if cond.isDelete() {
+					return false, nil
+				}
+
+				current, err := utils.GetFieldStringValue(v, cond.field.GetPath())
+				if err != nil {
+					return false, err
+				}
+				if current != state {
+					state = current
+					fmt.Fprintf(cmd.ErrOrStderr(), "Waiting for %s %s: %s=%s\n", kind.use, id, cond.field.ID, state)
+				}
+				return strings.EqualFold(current, cond.value), nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to wait for %s %s: %w", kind.use, id, err)
+			}
+
+			if cond.isDelete() {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s %s is deleted\n", kind.use, id)
+				return nil
+			}
+
+			formatter := cmdContext.GetOrCreateFormatter(cmd)
+			return formatter.Format(item)
+		},
+	}
+
+	cmd.Flags().StringVar(&forFlag, "for", "", "Condition to wait for: 'field=value' or 'delete'")
+	cmd.Flags().DurationVar(&waitOpts.Timeout, "wait-timeout", 30*time.Minute, "Maximum time to wait for the condition")
+	cmd.Flags().DurationVar(&waitOpts.PollInterval, "poll-interval", 5*time.Second, "Initial interval between status checks")
+	waitOpts.AddBackoffFlags(cmd)
+	_ = cmd.MarkFlagRequired("for")
+
+	return cmd
+}
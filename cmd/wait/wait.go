@@ -0,0 +1,134 @@
+package wait
+
+import (
+	"context"
+	"log"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/cmd/base"
+	"github.com/serverscom/srvctl/cmd/entities/hosts"
+	loadbalancers "github.com/serverscom/srvctl/cmd/entities/load_balancers"
+	"github.com/serverscom/srvctl/cmd/entities/ssl"
+	"github.com/serverscom/srvctl/internal/output/entities"
+	"github.com/spf13/cobra"
+)
+
+// Getter fetches a resource by its id
+type Getter interface {
+	Get(ctx context.Context, client *serverscom.Client, id string) (any, error)
+}
+
+// GetterFunc is an adapter to use ordinary functions as a Getter
+type GetterFunc func(ctx context.Context, client *serverscom.Client, id string) (any, error)
+
+func (f GetterFunc) Get(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+	return f(ctx, client, id)
+}
+
+// ResourceKind describes a resource which can be waited for
+type ResourceKind struct {
+	use    string
+	entity any
+	getter Getter
+}
+
+func resourceKinds() []ResourceKind {
+	return []ResourceKind{
+		{use: "ebm", entity: serverscom.DedicatedServer{}, getter: &hosts.EBMGetMgr{}},
+		{use: "kbm", entity: serverscom.KubernetesBaremetalNode{}, getter: &hosts.KBMGetMgr{}},
+		{use: "sbm", entity: serverscom.SBMServer{}, getter: &hosts.SBMGetMgr{}},
+		{use: "lb-l4", entity: serverscom.L4LoadBalancer{}, getter: &loadbalancers.LBL4GetMgr{}},
+		{use: "lb-l7", entity: serverscom.L7LoadBalancer{}, getter: &loadbalancers.LBL7GetMgr{}},
+		{use: "ssl-custom", entity: serverscom.SSLCertificateCustom{}, getter: &ssl.SSLCustomGetMgr{}},
+		{use: "ssl-le", entity: serverscom.SSLCertificateLE{}, getter: &ssl.SSLLeGetMgr{}},
+		{
+			use:    "lb-clusters",
+			entity: serverscom.LoadBalancerCluster{},
+			getter: GetterFunc(func(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+				return client.LoadBalancerClusters.GetLoadBalancerCluster(ctx, id)
+			}),
+		},
+		{
+			use:    "cloud-instances",
+			entity: serverscom.CloudComputingInstance{},
+			getter: GetterFunc(func(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+				return client.CloudComputingInstances.Get(ctx, id)
+			}),
+		},
+		{
+			use:    "cloud-volumes",
+			entity: serverscom.CloudBlockStorageVolume{},
+			getter: GetterFunc(func(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+				return client.CloudBlockStorageVolumes.Get(ctx, id)
+			}),
+		},
+		{
+			use:    "cloud-backups",
+			entity: serverscom.CloudBlockStorageBackup{},
+			getter: GetterFunc(func(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+				return client.CloudBlockStorageBackups.Get(ctx, id)
+			}),
+		},
+		{
+			use:    "rbs",
+			entity: serverscom.RemoteBlockStorageVolume{},
+			getter: GetterFunc(func(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+				return client.RemoteBlockStorageVolumes.Get(ctx, id)
+			}),
+		},
+		{
+			use:    "l2-segments",
+			entity: serverscom.L2Segment{},
+			getter: GetterFunc(func(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+				return client.L2Segments.Get(ctx, id)
+			}),
+		},
+		{
+			use:    "network-pools",
+			entity: serverscom.NetworkPool{},
+			getter: GetterFunc(func(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+				return client.NetworkPools.Get(ctx, id)
+			}),
+		},
+		{
+			use:    "k8s",
+			entity: serverscom.KubernetesCluster{},
+			getter: GetterFunc(func(ctx context.Context, client *serverscom.Client, id string) (any, error) {
+				return client.KubernetesClusters.Get(ctx, id)
+			}),
+		},
+	}
+}
+
+func NewCmd(cmdContext *base.CmdContext) *cobra.Command {
+	kinds := resourceKinds()
+
+	entitiesMap := make(map[string]entities.EntityInterface)
+	for _, kind := range kinds {
+		entity, err := entities.Registry.GetEntityFromValue(kind.entity)
+		if err != nil {
+			log.Fatal(err)
+		}
+		entitiesMap[kind.use] = entity
+	}
+
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait for a condition on a resource",
+		Long:  `Wait until a resource field has the expected value or the resource is deleted`,
+		PersistentPreRunE: base.CombinePreRunE(
+			base.CheckFormatterFlags(cmdContext, entitiesMap),
+			base.CheckEmptyContexts(cmdContext),
+		),
+		Args: base.NoArgs,
+		Run:  base.UsageRun,
+	}
+
+	for _, kind := range kinds {
+		cmd.AddCommand(newWaitKindCmd(cmdContext, kind, entitiesMap[kind.use]))
+	}
+
+	base.AddFormatFlags(cmd)
+
+	return cmd
+}
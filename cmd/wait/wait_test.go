@@ -0,0 +1,160 @@
+package wait
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/cmd/testutils"
+	"github.com/serverscom/srvctl/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	fixtureBasePath     = filepath.Join("..", "..", "testdata", "entities", "cloud-instances")
+	fixedTime           = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	testCloudInstanceID = "testInstanceId01"
+	testCloudInstance   = serverscom.CloudComputingInstance{
+		ID:                 testCloudInstanceID,
+		Name:               "test-instance",
+		RegionID:           1,
+		RegionCode:         "AMS1",
+		OpenstackUUID:      "uuid-123",
+		Status:             "active",
+		FlavorID:           "flavor-1",
+		FlavorName:         "m1.small",
+		ImageID:            "image-1",
+		ImageName:          new("Ubuntu 20.04"),
+		PublicIPv4Address:  new("1.2.3.4"),
+		PrivateIPv4Address: new("10.0.0.1"),
+		LocalIPv4Address:   new("192.168.0.1"),
+		PublicIPv6Address:  new("2001:db8::1"),
+		GPNEnabled:         true,
+		IPv6Enabled:        true,
+		BackupCopies:       2,
+		PublicPortBlocked:  false,
+		Labels:             map[string]string{"env": "test"},
+		Created:            fixedTime,
+		Updated:            fixedTime,
+	}
+)
+
+func TestWaitCloudInstanceCmd(t *testing.T) {
+	building := testCloudInstance
+	building.Status = "BUILD"
+
+	testCases := []struct {
+		name           string
+		args           []string
+		configureMock  func(*mocks.MockCloudComputingInstancesService)
+		expectedOutput []byte
+		expectError    bool
+	}{
+		{
+			name:           "wait for status",
+			args:           []string{"--for", "status=ACTIVE", "--output", "json"},
+			expectedOutput: testutils.ReadFixture(filepath.Join(fixtureBasePath, "get.json")),
+			configureMock: func(mock *mocks.MockCloudComputingInstancesService) {
+				gomock.InOrder(
+					mock.EXPECT().
+						Get(gomock.Any(), testCloudInstanceID).
+						Return(&building, nil),
+					mock.EXPECT().
+						Get(gomock.Any(), testCloudInstanceID).
+						Return(&testCloudInstance, nil),
+				)
+			},
+		},
+		{
+			name: "wait for delete",
+			args: []string{"--for", "delete"},
+			configureMock: func(mock *mocks.MockCloudComputingInstancesService) {
+				gomock.InOrder(
+					mock.EXPECT().
+						Get(gomock.Any(), testCloudInstanceID).
+						Return(&testCloudInstance, nil),
+					mock.EXPECT().
+						Get(gomock.Any(), testCloudInstanceID).
+						Return(nil, &serverscom.NotFoundError{StatusCode: 404, Message: "not found"}),
+				)
+			},
+		},
+		{
+			name: "wait with error",
+			args: []string{"--for", "status=ACTIVE"},
+			configureMock: func(mock *mocks.MockCloudComputingInstancesService) {
+				mock.EXPECT().
+					Get(gomock.Any(), testCloudInstanceID).
+					Return(nil, errors.New("some error"))
+			},
+			expectError: true,
+		},
+		{
+			name:        "wait for unknown field",
+			args:        []string{"--for", "unknown=value"},
+			expectError: true,
+		},
+		{
+			name:        "wait with invalid condition",
+			args:        []string{"--for", "status"},
+			expectError: true,
+		},
+		{
+			name: "wait with timeout",
+			args: []string{"--for", "status=ACTIVE", "--wait-timeout", "5ms"},
+			configureMock: func(mock *mocks.MockCloudComputingInstancesService) {
+				mock.EXPECT().
+					Get(gomock.Any(), testCloudInstanceID).
+					Return(&building, nil).
+					MinTimes(1)
+			},
+			expectError: true,
+		},
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	cloudServiceHandler := mocks.NewMockCloudComputingInstancesService(mockCtrl)
+
+	scClient := serverscom.NewClientWithEndpoint("", "")
+	scClient.CloudComputingInstances = cloudServiceHandler
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			if tc.configureMock != nil {
+				tc.configureMock(cloudServiceHandler)
+			}
+
+			testCmdContext := testutils.NewTestCmdContext(scClient)
+			waitCmd := NewCmd(testCmdContext)
+
+			args := []string{"wait", "cloud-instances", testCloudInstanceID, "--poll-interval", "1ms"}
+			args = append(args, tc.args...)
+
+			builder := testutils.NewTestCommandBuilder().
+				WithCommand(waitCmd).
+				WithArgs(args)
+
+			cmd := builder.Build()
+
+			err := cmd.Execute()
+
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(BeNil())
+				if tc.expectedOutput != nil {
+					g.Expect(builder.GetOutput()).To(MatchJSON(tc.expectedOutput))
+				} else {
+					g.Expect(builder.GetOutput()).To(BeEmpty())
+				}
+			}
+		})
+	}
+}
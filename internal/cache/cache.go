@@ -0,0 +1,169 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fileExt is an extension of cache entry files
+const fileExt = ".json"
+
+// Cache stores API responses on disk for a limited time
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// entry represents a cached response
+type entry struct {
+	Key     string          `json:"key"`
+	Created time.Time       `json:"created"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Info represents cache statistics
+type Info struct {
+	Dir     string     `json:"dir"`
+	TTL     string     `json:"ttl"`
+	Entries int        `json:"entries"`
+	Expired int        `json:"expired"`
+	Size    int64      `json:"size"`
+	Oldest  *time.Time `json:"oldest,omitempty"`
+	Newest  *time.Time `json:"newest,omitempty"`
+}
+
+// New creates a new Cache storing entries in dir
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{
+		dir: dir,
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+// Dir returns cache directory
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// TTL returns time entries are valid for
+func (c *Cache) TTL() time.Duration {
+	return c.ttl
+}
+
+// Get reads a not expired value for key into v, returns false if there is no such value
+func (c *Cache) Get(key string, v any) bool {
+	e, err := readEntry(c.path(key))
+	if err != nil || e.Key != key || c.expired(e) {
+		return false
+	}
+	return json.Unmarshal(e.Data, v) == nil
+}
+
+// Set stores value v for key
+func (c *Cache) Set(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	e, err := json.Marshal(entry{Key: key, Created: c.now(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+	// write to a temporary file first so parallel commands never read a partial entry
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(e); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// Clear removes all cached entries
+func (c *Cache) Clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+// Info returns cache statistics
+func (c *Cache) Info() (*Info, error) {
+	info := &Info{Dir: c.dir, TTL: c.ttl.String()}
+
+	files, err := os.ReadDir(c.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return info, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), fileExt) {
+			continue
+		}
+		fi, err := f.Info()
+		if err != nil {
+			return nil, err
+		}
+		e, err := readEntry(filepath.Join(c.dir, f.Name()))
+		if err != nil {
+			continue
+		}
+
+		info.Entries++
+		info.Size += fi.Size()
+		if c.expired(e) {
+			info.Expired++
+		}
+		if info.Oldest == nil || e.Created.Before(*info.Oldest) {
+			info.Oldest = &e.Created
+		}
+		if info.Newest == nil || e.Created.After(*info.Newest) {
+			info.Newest = &e.Created
+		}
+	}
+	return info, nil
+}
+
+// expired returns true if entry is older than cache TTL
+func (c *Cache) expired(e *entry) bool {
+	return c.now().Sub(e.Created) >= c.ttl
+}
+
+// path returns path to the entry file for key
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+fileExt)
+}
+
+// readEntry reads cache entry from file
+func readEntry(path string) (*entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
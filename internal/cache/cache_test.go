@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+type testValue struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestCache(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := New(t.TempDir(), time.Hour)
+	c.now = func() time.Time { return now }
+
+	var v testValue
+	g.Expect(c.Get("key", &v)).To(BeFalse())
+
+	g.Expect(c.Set("key", testValue{ID: 1, Name: "test"})).To(Succeed())
+	g.Expect(c.Get("key", &v)).To(BeTrue())
+	g.Expect(v).To(Equal(testValue{ID: 1, Name: "test"}))
+	g.Expect(c.Get("other", &v)).To(BeFalse())
+
+	now = now.Add(30 * time.Minute)
+	g.Expect(c.Set("other", []testValue{{ID: 2}})).To(Succeed())
+
+	info, err := c.Info()
+	g.Expect(err).To(BeNil())
+	g.Expect(info.Entries).To(Equal(2))
+	g.Expect(info.Expired).To(BeZero())
+	g.Expect(info.TTL).To(Equal("1h0m0s"))
+	g.Expect(info.Size).To(BeNumerically(">", 0))
+	g.Expect(*info.Oldest).To(Equal(now.Add(-30 * time.Minute)))
+	g.Expect(*info.Newest).To(Equal(now))
+
+	// first entry is expired
+	now = now.Add(45 * time.Minute)
+	g.Expect(c.Get("key", &v)).To(BeFalse())
+	var list []testValue
+	g.Expect(c.Get("other", &list)).To(BeTrue())
+	g.Expect(list).To(Equal([]testValue{{ID: 2}}))
+
+	info, err = c.Info()
+	g.Expect(err).To(BeNil())
+	g.Expect(info.Expired).To(Equal(1))
+
+	g.Expect(c.Clear()).To(Succeed())
+	g.Expect(c.Get("other", &list)).To(BeFalse())
+
+	info, err = c.Info()
+	g.Expect(err).To(BeNil())
+	g.Expect(info.Entries).To(BeZero())
+}
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/cache"
+)
+
+// SetCache makes client store responses of catalog requests (locations and
+// order options) in cache and reuse them while they are not expired
+func (c *Client) SetCache(cache *cache.Cache) *Client {
+	if c.cache != nil {
+		return c
+	}
+	c.cache = cache
+
+	sc := c.scClient
+	sc.Locations = &cachedLocations{sc.Locations, cache}
+	return c
+}
+
+// GetCache returns cache used by client, nil if responses are not cached
+func (c *Client) GetCache() *cache.Cache {
+	return c.cache
+}
+
+// cached returns value stored for key or calls fn and stores its result.
+// Cache write errors are ignored since the response is already received.
+func cached[T any](c *cache.Cache, key string, fn func() (T, error)) (T, error) {
+	var v T
+	if c.Get(key, &v) {
+		return v, nil
+	}
+
+	v, err := fn()
+	if err != nil {
+		return v, err
+	}
+	_ = c.Set(key, v)
+	return v, nil
+}
+
+// cachedCollection caches List and Collect results of a collection, the
+// cache key includes params set to the collection
+type cachedCollection[T any] struct {
+	serverscom.Collection[T]
+	cache  *cache.Cache
+	path   string
+	params url.Values
+}
+
+func newCachedCollection[T any](c *cache.Cache, collection serverscom.Collection[T], format string, args ...any) serverscom.Collection[T] {
+	return &cachedCollection[T]{
+		Collection: collection,
+		cache:      c,
+		path:       fmt.Sprintf(format, args...),
+		params:     url.Values{},
+	}
+}
+
+func (cc *cachedCollection[T]) SetParam(name, value string) serverscom.Collection[T] {
+	cc.Collection.SetParam(name, value)
+	cc.params.Set(name, value)
+	return cc
+}
+
+func (cc *cachedCollection[T]) SetPage(page int) serverscom.Collection[T] {
+	cc.Collection.SetPage(page)
+	cc.params.Set("page", strconv.Itoa(page))
+	return cc
+}
+
+func (cc *cachedCollection[T]) SetPerPage(perPage int) serverscom.Collection[T] {
+	cc.Collection.SetPerPage(perPage)
+	cc.params.Set("per_page", strconv.Itoa(perPage))
+	return cc
+}
+
+func (cc *cachedCollection[T]) List(ctx context.Context) ([]T, error) {
+	return cached(cc.cache, cc.key("list"), func() ([]T, error) {
+		return cc.Collection.List(ctx)
+	})
+}
+
+func (cc *cachedCollection[T]) Collect(ctx context.Context) ([]T, error) {
+	return cached(cc.cache, cc.key("collect"), func() ([]T, error) {
+		return cc.Collection.Collect(ctx)
+	})
+}
+
+// key returns cache key for the collection request
+func (cc *cachedCollection[T]) key(method string) string {
+	return fmt.Sprintf("%s %s?%s", method, cc.path, cc.params.Encode())
+}
+
+// cachedLocations caches catalog data which almost never changes
+type cachedLocations struct {
+	serverscom.LocationsService
+	cache *cache.Cache
+}
+
+func (s *cachedLocations) Collection() serverscom.Collection[serverscom.Location] {
+	return newCachedCollection(s.cache, s.LocationsService.Collection(), "/locations")
+}
+
+func (s *cachedLocations) GetLocation(ctx context.Context, locationID int64) (*serverscom.Location, error) {
+	return cached(s.cache, fmt.Sprintf("get /locations/%d", locationID), func() (*serverscom.Location, error) {
+		return s.LocationsService.GetLocation(ctx, locationID)
+	})
+}
+
+func (s *cachedLocations) ServerModelOptions(locationID int64) serverscom.Collection[serverscom.ServerModelOption] {
+	return newCachedCollection(s.cache, s.LocationsService.ServerModelOptions(locationID),
+		"/locations/%d/order_options/server_models", locationID)
+}
+
+func (s *cachedLocations) GetServerModelOption(ctx context.Context, locationID, serverModelID int64) (*serverscom.ServerModelOptionDetail, error) {
+	key := fmt.Sprintf("get /locations/%d/order_options/server_models/%d", locationID, serverModelID)
+	return cached(s.cache, key, func() (*serverscom.ServerModelOptionDetail, error) {
+		return s.LocationsService.GetServerModelOption(ctx, locationID, serverModelID)
+	})
+}
+
+func (s *cachedLocations) RAMOptions(locationID, serverModelID int64) serverscom.Collection[serverscom.RAMOption] {
+	return newCachedCollection(s.cache, s.LocationsService.RAMOptions(locationID, serverModelID),
+		"/locations/%d/order_options/server_models/%d/ram", locationID, serverModelID)
+}
+
+func (s *cachedLocations) OperatingSystemOptions(locationID, serverModelID int64) serverscom.Collection[serverscom.OperatingSystemOption] {
+	return newCachedCollection(s.cache, s.LocationsService.OperatingSystemOptions(locationID, serverModelID),
+		"/locations/%d/order_options/server_models/%d/operating_systems", locationID, serverModelID)
+}
+
+func (s *cachedLocations) GetOperatingSystemOption(ctx context.Context, locationID, serverModelID, operatingSystemID int64) (*serverscom.OperatingSystemOption, error) {
+	key := fmt.Sprintf("get /locations/%d/order_options/server_models/%d/operating_systems/%d", locationID, serverModelID, operatingSystemID)
+	return cached(s.cache, key, func() (*serverscom.OperatingSystemOption, error) {
+		return s.LocationsService.GetOperatingSystemOption(ctx, locationID, serverModelID, operatingSystemID)
+	})
+}
+
+func (s *cachedLocations) DriveModelOptions(locationID, serverModelID int64) serverscom.Collection[serverscom.DriveModel] {
+	return newCachedCollection(s.cache, s.LocationsService.DriveModelOptions(locationID, serverModelID),
+		"/locations/%d/order_options/server_models/%d/drive_models", locationID, serverModelID)
+}
+
+func (s *cachedLocations) GetDriveModelOption(ctx context.Context, locationID, serverModelID, driveModelID int64) (*serverscom.DriveModel, error) {
+	key := fmt.Sprintf("get /locations/%d/order_options/server_models/%d/drive_models/%d", locationID, serverModelID, driveModelID)
+	return cached(s.cache, key, func() (*serverscom.DriveModel, error) {
+		return s.LocationsService.GetDriveModelOption(ctx, locationID, serverModelID, driveModelID)
+	})
+}
+
+func (s *cachedLocations) UplinkOptions(locationID, serverModelID int64) serverscom.Collection[serverscom.UplinkOption] {
+	return newCachedCollection(s.cache, s.LocationsService.UplinkOptions(locationID, serverModelID),
+		"/locations/%d/order_options/server_models/%d/uplink_models", locationID, serverModelID)
+}
+
+func (s *cachedLocations) GetUplinkOption(ctx context.Context, locationID, serverModelID, uplinkModelID int64) (*serverscom.UplinkOption, error) {
+	key := fmt.Sprintf("get /locations/%d/order_options/server_models/%d/uplink_models/%d", locationID, serverModelID, uplinkModelID)
+	return cached(s.cache, key, func() (*serverscom.UplinkOption, error) {
+		return s.LocationsService.GetUplinkOption(ctx, locationID, serverModelID, uplinkModelID)
+	})
+}
+
+func (s *cachedLocations) BandwidthOptions(locationID, serverModelID, uplinkID int64) serverscom.Collection[serverscom.BandwidthOption] {
+	return newCachedCollection(s.cache, s.LocationsService.BandwidthOptions(locationID, serverModelID, uplinkID),
+		"/locations/%d/order_options/server_models/%d/uplink_models/%d/bandwidth", locationID, serverModelID, uplinkID)
+}
+
+func (s *cachedLocations) GetBandwidthOption(ctx context.Context, locationID, serverModelID, uplinkModelID, bandwidthID int64) (*serverscom.BandwidthOption, error) {
+	key := fmt.Sprintf("get /locations/%d/order_options/server_models/%d/uplink_models/%d/bandwidth/%d", locationID, serverModelID, uplinkModelID, bandwidthID)
+	return cached(s.cache, key, func() (*serverscom.BandwidthOption, error) {
+		return s.LocationsService.GetBandwidthOption(ctx, locationID, serverModelID, uplinkModelID, bandwidthID)
+	})
+}
+
+func (s *cachedLocations) SBMFlavorOptions(locationID int64) serverscom.Collection[serverscom.SBMFlavor] {
+	return newCachedCollection(s.cache, s.LocationsService.SBMFlavorOptions(locationID),
+		"/locations/%d/order_options/sbm_flavor_models", locationID)
+}
+
+func (s *cachedLocations) GetSBMFlavorOption(ctx context.Context, locationID, sbmFlavorModelID int64) (*serverscom.SBMFlavor, error) {
+	key := fmt.Sprintf("get /locations/%d/order_options/sbm_flavor_models/%d", locationID, sbmFlavorModelID)
+	return cached(s.cache, key, func() (*serverscom.SBMFlavor, error) {
+		return s.LocationsService.GetSBMFlavorOption(ctx, locationID, sbmFlavorModelID)
+	})
+}
+
+func (s *cachedLocations) SBMOperatingSystemOptions(locationID, sbmFlavorModelID int64) serverscom.Collection[serverscom.OperatingSystemOption] {
+	return newCachedCollection(s.cache, s.LocationsService.SBMOperatingSystemOptions(locationID, sbmFlavorModelID),
+		"/locations/%d/order_options/sbm_flavor_models/%d/operating_systems", locationID, sbmFlavorModelID)
+}
+
+func (s *cachedLocations) GetSBMOperatingSystemOption(ctx context.Context, locationID, sbmFlavorModelID, operatingSystemID int64) (*serverscom.OperatingSystemOption, error) {
+	key := fmt.Sprintf("get /locations/%d/order_options/sbm_flavor_models/%d/operating_systems/%d", locationID, sbmFlavorModelID, operatingSystemID)
+	return cached(s.cache, key, func() (*serverscom.OperatingSystemOption, error) {
+		return s.LocationsService.GetSBMOperatingSystemOption(ctx, locationID, sbmFlavorModelID, operatingSystemID)
+	})
+}
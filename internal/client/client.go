@@ -2,13 +2,18 @@ package client
 
 import (
 	"context"
+	"net/http"
 
 	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/cache"
 )
 
 // Client wraps serverscom API client
 type Client struct {
-	scClient *serverscom.Client
+	scClient   *serverscom.Client
+	httpClient *http.Client
+	dryRun     RequestHandler
+	cache      *cache.Cache
 }
 
 func NewClient(token string, endpoint string) *Client {
@@ -49,3 +54,36 @@ func (c *Client) SetUserAgent(agent string) *Client {
 	c.scClient.SetupUserAgent(agent)
 	return c
 }
+
+// SetHTTPClient sets the HTTP client sending API requests
+func (c *Client) SetHTTPClient(httpClient *http.Client) *Client {
+	c.httpClient = httpClient
+	c.installHTTPClient()
+	return c
+}
+
+// installHTTPClient passes the HTTP client to the API client
+func (c *Client) installHTTPClient() {
+	c.scClient.SetHTTPClient(c.GetHTTPClient())
+}
+
+// GetHTTPClient returns the HTTP client sending API requests: the one set by
+// SetHTTPClient or a default one. In dry run mode its transport passes mutating
+// requests to the dry run handler.
+func (c *Client) GetHTTPClient() *http.Client {
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	if c.dryRun == nil {
+		return httpClient
+	}
+
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	dryRunClient := *httpClient
+	dryRunClient.Transport = &dryRunTransport{next: transport, handler: c.dryRun}
+	return &dryRunClient
+}
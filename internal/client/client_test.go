@@ -0,0 +1,413 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/cache"
+	"github.com/serverscom/srvctl/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDryRun(t *testing.T) {
+	testCases := []struct {
+		name            string
+		method          string
+		path            string
+		body            string
+		handlerErr      error
+		expectedRequest *Request
+		expectedErr     error
+		expectedSent    int32
+	}{
+		{
+			name:   "create ssh key",
+			method: http.MethodPost,
+			path:   "/ssh_keys",
+			body:   `{"name":"test","public_key":"ssh-rsa AAAA"}`,
+			expectedRequest: &Request{
+				Method: "POST",
+				URL:    "/ssh_keys",
+				Body:   json.RawMessage(`{"name":"test","public_key":"ssh-rsa AAAA"}`),
+			},
+			expectedErr: ErrDryRun,
+		},
+		{
+			name:            "delete ssh key",
+			method:          http.MethodDelete,
+			path:            "/ssh_keys/fingerprint",
+			expectedRequest: &Request{Method: "DELETE", URL: "/ssh_keys/fingerprint"},
+			expectedErr:     ErrDryRun,
+		},
+		{
+			name:            "power off ebm server",
+			method:          http.MethodPost,
+			path:            "/hosts/dedicated_servers/testId/power_off",
+			expectedRequest: &Request{Method: "POST", URL: "/hosts/dedicated_servers/testId/power_off"},
+			expectedErr:     ErrDryRun,
+		},
+		{
+			name:            "handler error",
+			method:          http.MethodDelete,
+			path:            "/ssh_keys/fingerprint",
+			handlerErr:      errors.New("some error"),
+			expectedRequest: &Request{Method: "DELETE", URL: "/ssh_keys/fingerprint"},
+			expectedErr:     errors.New("some error"),
+		},
+		{
+			name:         "get ssh key is sent",
+			method:       http.MethodGet,
+			path:         "/ssh_keys/fingerprint",
+			expectedSent: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var sent atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sent.Add(1)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			var requests []*Request
+			c := NewWithClient(serverscom.NewClientWithEndpoint("", server.URL)).SetDryRun(func(req *Request) error {
+				// the server address is random, so only the path is compared
+				req.URL = strings.TrimPrefix(req.URL, server.URL)
+				requests = append(requests, req)
+				return tc.handlerErr
+			})
+			g.Expect(c.IsDryRun()).To(BeTrue())
+
+			req, err := http.NewRequest(tc.method, server.URL+tc.path, strings.NewReader(tc.body))
+			g.Expect(err).To(BeNil())
+			resp, err := c.GetHTTPClient().Do(req)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+
+			switch {
+			case errors.Is(tc.expectedErr, ErrDryRun):
+				// the HTTP client wraps errors of the transport
+				g.Expect(errors.Is(err, ErrDryRun)).To(BeTrue())
+			case tc.expectedErr != nil:
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedErr.Error())))
+			default:
+				g.Expect(err).To(BeNil())
+			}
+			if tc.expectedRequest != nil {
+				g.Expect(requests).To(Equal([]*Request{tc.expectedRequest}))
+			} else {
+				g.Expect(requests).To(BeEmpty())
+			}
+			g.Expect(sent.Load()).To(Equal(tc.expectedSent))
+		})
+	}
+}
+
+func TestDryRunKeepsHTTPClient(t *testing.T) {
+	g := NewWithT(t)
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewWithClient(serverscom.NewClientWithEndpoint("", server.URL)).
+		SetHTTPClient(&http.Client{Transport: NewRetryTransport(http.DefaultTransport, RetryPolicy{Retries: 1, BaseWait: time.Millisecond})}).
+		SetDryRun(func(req *Request) error { return nil })
+
+	// read requests are still sent with the transport set before
+	resp, err := c.GetHTTPClient().Get(server.URL + "/ssh_keys")
+	g.Expect(err).To(BeNil())
+	_ = resp.Body.Close()
+	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	g.Expect(attempts.Load()).To(Equal(int32(2)))
+
+	_, err = c.GetHTTPClient().Post(server.URL+"/ssh_keys", "application/json", strings.NewReader(`{}`))
+	g.Expect(errors.Is(err, ErrDryRun)).To(BeTrue())
+	g.Expect(attempts.Load()).To(Equal(int32(2)))
+}
+
+func TestCache(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	locationsServiceHandler := mocks.NewMockLocationsService(mockCtrl)
+	collectionHandler := mocks.NewMockCollection[serverscom.ServerModelOption](mockCtrl)
+
+	models := []serverscom.ServerModelOption{{ID: 1, Name: "model"}}
+	model := &serverscom.ServerModelOptionDetail{ID: 1, Name: "model"}
+
+	locationsServiceHandler.EXPECT().
+		ServerModelOptions(int64(1)).
+		Return(collectionHandler).
+		Times(3)
+	collectionHandler.EXPECT().
+		SetParam(gomock.Any(), gomock.Any()).
+		Return(collectionHandler).
+		AnyTimes()
+	// the same request is sent only once, requests with other params are sent separately
+	collectionHandler.EXPECT().
+		List(gomock.Any()).
+		Return(models, nil).
+		Times(2)
+	locationsServiceHandler.EXPECT().
+		GetServerModelOption(gomock.Any(), int64(1), int64(1)).
+		Return(model, nil)
+
+	scClient := serverscom.NewClientWithEndpoint("", "")
+	scClient.Locations = locationsServiceHandler
+
+	c := NewWithClient(scClient).SetCache(cache.New(t.TempDir(), time.Hour))
+	g.Expect(c.GetCache()).NotTo(BeNil())
+
+	ctx := context.Background()
+	locations := c.GetScClient().Locations
+
+	for range 2 {
+		items, err := locations.ServerModelOptions(1).List(ctx)
+		g.Expect(err).To(BeNil())
+		g.Expect(items).To(Equal(models))
+
+		item, err := locations.GetServerModelOption(ctx, 1, 1)
+		g.Expect(err).To(BeNil())
+		g.Expect(item).To(Equal(model))
+	}
+
+	items, err := locations.ServerModelOptions(1).SetParam("search_pattern", "model").List(ctx)
+	g.Expect(err).To(BeNil())
+	g.Expect(items).To(Equal(models))
+}
+
+func TestRetryTransport(t *testing.T) {
+	testCases := []struct {
+		name             string
+		method           string
+		policy           RetryPolicy
+		responses        []int
+		retryAfter       string
+		expectedStatus   int
+		expectedAttempts int
+	}{
+		{
+			name:             "retry 5xx until success",
+			method:           http.MethodGet,
+			policy:           RetryPolicy{Retries: 3},
+			responses:        []int{502, 503, 200},
+			expectedStatus:   200,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "retry 429 after Retry-After",
+			method:           http.MethodGet,
+			policy:           RetryPolicy{Retries: 3, MaxWait: time.Second},
+			responses:        []int{429, 200},
+			retryAfter:       "0",
+			expectedStatus:   200,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "don't wait for Retry-After longer than max wait",
+			method:           http.MethodGet,
+			policy:           RetryPolicy{Retries: 3, MaxWait: time.Second},
+			responses:        []int{429, 200},
+			retryAfter:       "120",
+			expectedStatus:   429,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "give up after retries",
+			method:           http.MethodDelete,
+			policy:           RetryPolicy{Retries: 2},
+			responses:        []int{500, 500, 500, 200},
+			expectedStatus:   500,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "don't retry client errors",
+			method:           http.MethodGet,
+			policy:           RetryPolicy{Retries: 3},
+			responses:        []int{404, 200},
+			expectedStatus:   404,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "don't retry POST by default",
+			method:           http.MethodPost,
+			policy:           RetryPolicy{Retries: 3},
+			responses:        []int{503, 200},
+			expectedStatus:   503,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retry POST if enabled",
+			method:           http.MethodPost,
+			policy:           RetryPolicy{Retries: 3, RetryNonIdempotent: true},
+			responses:        []int{503, 200},
+			expectedStatus:   200,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "retries disabled",
+			method:           http.MethodGet,
+			policy:           RetryPolicy{},
+			responses:        []int{503, 200},
+			expectedStatus:   503,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := int(attempts.Add(1)) - 1
+				body, _ := io.ReadAll(r.Body)
+				if r.Method == http.MethodPost {
+					// the body is sent again on every attempt
+					g.Expect(string(body)).To(Equal(`{"name":"test"}`))
+				}
+				if tc.retryAfter != "" {
+					w.Header().Set("Retry-After", tc.retryAfter)
+				}
+				w.WriteHeader(tc.responses[attempt])
+			}))
+			defer server.Close()
+
+			tc.policy.BaseWait = time.Millisecond
+			httpClient := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, tc.policy)}
+
+			req, err := http.NewRequest(tc.method, server.URL, strings.NewReader(`{"name":"test"}`))
+			g.Expect(err).To(BeNil())
+
+			resp, err := httpClient.Do(req)
+			g.Expect(err).To(BeNil())
+			defer resp.Body.Close() //nolint:errcheck
+
+			g.Expect(resp.StatusCode).To(Equal(tc.expectedStatus))
+			g.Expect(int(attempts.Load())).To(Equal(tc.expectedAttempts))
+		})
+	}
+}
+
+func TestRetryTransportNetworkError(t *testing.T) {
+	g := NewWithT(t)
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// drop the connection without a response
+			conn, _, err := w.(http.Hijacker).Hijack()
+			g.Expect(err).To(BeNil())
+			_ = conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := NewRetryTransport(http.DefaultTransport, RetryPolicy{Retries: 1, BaseWait: time.Millisecond})
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	g.Expect(err).To(BeNil())
+	defer resp.Body.Close() //nolint:errcheck
+
+	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	g.Expect(int(attempts.Load())).To(Equal(2))
+}
+
+func TestRetryTransportAttemptTimeout(t *testing.T) {
+	g := NewWithT(t)
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// the first attempt is slower than its timeout
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	transport := NewRetryTransport(http.DefaultTransport, RetryPolicy{
+		Retries:        1,
+		BaseWait:       time.Millisecond,
+		AttemptTimeout: 100 * time.Millisecond,
+	})
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	g.Expect(err).To(BeNil())
+	defer resp.Body.Close() //nolint:errcheck
+
+	// the body is read after the transport returned the response
+	body, err := io.ReadAll(resp.Body)
+	g.Expect(err).To(BeNil())
+	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	g.Expect(string(body)).To(Equal(`[]`))
+	g.Expect(int(attempts.Load())).To(Equal(2))
+}
+
+func TestRetryTransportAttemptTimeoutWithoutRetries(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	transport := NewRetryTransport(http.DefaultTransport, RetryPolicy{AttemptTimeout: 50 * time.Millisecond})
+	_, err := (&http.Client{Transport: transport}).Get(server.URL)
+	// the real error is returned instead of a response
+	g.Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+}
+
+func TestResponseHeaderTransport(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<https://api.servers.com/v1/ssh_keys?page=3>; rel="last"`)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: NewResponseHeaderTransport(http.DefaultTransport)}
+
+	var header http.Header
+	req, err := http.NewRequestWithContext(WithResponseHeader(context.Background(), &header), http.MethodGet, server.URL, nil)
+	g.Expect(err).To(BeNil())
+	resp, err := httpClient.Do(req)
+	g.Expect(err).To(BeNil())
+	_ = resp.Body.Close()
+	g.Expect(header.Get("Link")).To(Equal(`<https://api.servers.com/v1/ssh_keys?page=3>; rel="last"`))
+
+	// requests without a header to store to are sent as is
+	resp, err = httpClient.Get(server.URL)
+	g.Expect(err).To(BeNil())
+	_ = resp.Body.Close()
+	g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+}
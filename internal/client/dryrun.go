@@ -0,0 +1,76 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+)
+
+// ErrDryRun is returned instead of sending a mutating request in dry run mode
+var ErrDryRun = errors.New("dry run: request is not sent")
+
+// readMethods are sent in dry run mode, requests with other methods change resources
+var readMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
+
+// Request describes a mutating API request
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   any    `json:"body,omitempty"`
+}
+
+// RequestHandler is called with a request which would be sent in dry run mode
+type RequestHandler func(req *Request) error
+
+// SetDryRun makes the HTTP client pass mutating requests to handler instead of
+// sending them, read requests are still sent. Mutating calls return ErrDryRun
+// after handler succeeds.
+func (c *Client) SetDryRun(handler RequestHandler) *Client {
+	if c.dryRun != nil {
+		return c
+	}
+	c.dryRun = handler
+	c.installHTTPClient()
+	return c
+}
+
+// IsDryRun returns true if dry run mode is enabled
+func (c *Client) IsDryRun() bool {
+	return c.dryRun != nil
+}
+
+// dryRunTransport passes mutating requests to handler instead of sending them
+// with next. Requests are intercepted after the API client built them, so the
+// handler gets the exact method, URL and body of every API call.
+type dryRunTransport struct {
+	next    http.RoundTripper
+	handler RequestHandler
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if slices.Contains(readMethods, req.Method) {
+		return t.next.RoundTrip(req)
+	}
+
+	r := &Request{Method: req.Method, URL: req.URL.String()}
+	if req.Body != nil && req.Body != http.NoBody {
+		data, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > 0 {
+			r.Body = string(data)
+			if json.Valid(data) {
+				r.Body = json.RawMessage(data)
+			}
+		}
+	}
+
+	if err := t.handler(r); err != nil {
+		return nil, err
+	}
+	return nil, ErrDryRun
+}
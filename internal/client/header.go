@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type responseHeaderKey struct{}
+
+// WithResponseHeader returns a copy of ctx which makes the transport returned by
+// NewResponseHeaderTransport store the header of the response to a request sent
+// with it to header. Collections don't expose response headers, e.g. the Link
+// header with the last page, so they are read this way.
+func WithResponseHeader(ctx context.Context, header *http.Header) context.Context {
+	return context.WithValue(ctx, responseHeaderKey{}, header)
+}
+
+// responseHeaderTransport stores response headers requested with WithResponseHeader
+type responseHeaderTransport struct {
+	next http.RoundTripper
+}
+
+// NewResponseHeaderTransport returns a transport sending requests with next and
+// storing headers of their responses requested with WithResponseHeader
+func NewResponseHeaderTransport(next http.RoundTripper) http.RoundTripper {
+	return &responseHeaderTransport{next: next}
+}
+
+func (t *responseHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if header, ok := req.Context().Value(responseHeaderKey{}).(*http.Header); ok && header != nil {
+		*header = resp.Header.Clone()
+	}
+	return resp, nil
+}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// idempotentMethods are retried by default, other methods only if RetryPolicy.RetryNonIdempotent is set
+var idempotentMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete}
+
+// defaultRetryBaseWait is the wait before the first retry, it doubles with every attempt
+const defaultRetryBaseWait = 500 * time.Millisecond
+
+// RetryPolicy defines how failed requests are retried
+type RetryPolicy struct {
+	// Retries is the max number of retries, 0 disables them
+	Retries int
+	// MaxWait limits the wait between attempts. Responses asking to retry
+	// later than MaxWait with Retry-After are returned as is.
+	MaxWait time.Duration
+	// BaseWait is the wait before the first retry, defaultRetryBaseWait if zero
+	BaseWait time.Duration
+	// RetryNonIdempotent enables retries of POST and PATCH requests
+	RetryNonIdempotent bool
+	// AttemptTimeout limits every attempt including reading its response body,
+	// waits between attempts aren't limited by it. Zero means no timeout.
+	AttemptTimeout time.Duration
+}
+
+// retryTransport retries requests failed with network errors, timeouts, 429 or
+// 5xx responses with jittered exponential backoff
+type retryTransport struct {
+	next   http.RoundTripper
+	policy RetryPolicy
+}
+
+// NewRetryTransport returns a transport retrying requests sent with next according to the policy
+func NewRetryTransport(next http.RoundTripper, policy RetryPolicy) http.RoundTripper {
+	if policy.BaseWait <= 0 {
+		policy.BaseWait = defaultRetryBaseWait
+	}
+	return &retryTransport{next: next, policy: policy}
+}
+
+// RoundTrip sends the request, retrying it while it's allowed by the policy
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retryable := t.policy.allows(req.Method)
+
+	// the body is sent again on every attempt
+	var body []byte
+	if retryable && req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := req.Context(), context.CancelFunc(func() {})
+		if t.policy.AttemptTimeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, t.policy.AttemptTimeout)
+		}
+
+		attemptReq := req.WithContext(ctx)
+		if body != nil {
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+			attemptReq.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(body)), nil
+			}
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		retry := retryable && attempt < t.policy.Retries && shouldRetry(req.Context(), resp, err)
+
+		var wait time.Duration
+		if retry {
+			wait, retry = t.wait(attempt, resp)
+		}
+		if !retry {
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			// the attempt timeout keeps limiting reading of the body
+			resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		if resp != nil {
+			// the connection is reused only if the body is read to the end
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		cancel()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// cancelBody cancels the context of the attempt once the body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// allows checks if requests with the method are retried
+func (p RetryPolicy) allows(method string) bool {
+	if p.Retries <= 0 {
+		return false
+	}
+	return p.RetryNonIdempotent || slices.Contains(idempotentMethods, method)
+}
+
+// wait returns the wait before the next attempt: Retry-After of the response if
+// it's set or a random wait up to the exponential backoff otherwise.
+// Returns false if the server asks to wait longer than MaxWait.
+func (t *retryTransport) wait(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if t.policy.MaxWait > 0 && wait > t.policy.MaxWait {
+				return 0, false
+			}
+			return wait, true
+		}
+	}
+
+	backoff := t.policy.BaseWait << min(attempt, 30)
+	if t.policy.MaxWait > 0 && (backoff > t.policy.MaxWait || backoff <= 0) {
+		backoff = t.policy.MaxWait
+	}
+	// full jitter spreads retries of concurrent clients
+	return rand.N(backoff) + 1, true
+}
+
+// shouldRetry checks if a request failed with a temporary error. Timeouts of
+// an attempt are retried, but not cancellation of the request itself.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		var netErr net.Error
+		return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) ||
+			errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode == http.StatusNotImplemented || resp.StatusCode == http.StatusHTTPVersionNotSupported:
+		return false
+	default:
+		return resp.StatusCode >= 500
+	}
+}
+
+// retryAfter parses Retry-After header, which is either seconds or an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
@@ -8,12 +8,15 @@ import (
 // ConfigOptions represents a map of configuration options
 type ConfigOptions = map[string]any
 
-// Context manages srvctl configuration
+// Context manages srvctl configuration.
+// Token is taken from one of Token, TokenFile or TokenCommand, see ResolveToken.
 type Context struct {
-	Name     string        `yaml:"name"`
-	Endpoint string        `yaml:"endpoint"`
-	Token    string        `yaml:"token"`
-	Config   ConfigOptions `yaml:"config"`
+	Name         string        `yaml:"name"`
+	Endpoint     string        `yaml:"endpoint"`
+	Token        string        `yaml:"token,omitempty"`
+	TokenFile    string        `yaml:"tokenFile,omitempty"`
+	TokenCommand string        `yaml:"tokenCommand,omitempty"`
+	Config       ConfigOptions `yaml:"config"`
 }
 
 // Config represents srvctl configuration
@@ -21,6 +24,8 @@ type Config struct {
 	GlobalConfig   ConfigOptions `yaml:"globalConfig"`
 	DefaultContext string        `yaml:"defaultContext"`
 	Contexts       []Context     `yaml:"contexts"`
+	// Encryption is set in the config file only, decrypted config is kept in memory without it
+	Encryption *Encryption `yaml:"encryption,omitempty"`
 }
 
 // getConfigPath returns config path from env in this priority:
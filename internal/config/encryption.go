@@ -0,0 +1,238 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+// Encryption modes
+const (
+	// EncryptionModeConfig encrypts the whole config
+	EncryptionModeConfig = "config"
+	// EncryptionModeTokens encrypts only tokens of contexts, the rest of the config stays readable
+	EncryptionModeTokens = "tokens"
+)
+
+const (
+	// PassphraseEnv is an env var with the config passphrase, it's asked on a TTY if it's not set
+	PassphraseEnv = "SRVCTL_PASSPHRASE"
+
+	kdfPBKDF2SHA256  = "pbkdf2-sha256"
+	kdfIterations    = 600000
+	keyLength        = 32
+	saltLength       = 16
+	encryptedPrefix  = "encrypted:"
+	passphraseMarker = "srvctl"
+)
+
+// Encryption describes how the config is encrypted. Data is the encrypted
+// config in config mode and an encrypted marker used to check the passphrase in tokens mode.
+type Encryption struct {
+	Mode       string `yaml:"mode"`
+	KDF        string `yaml:"kdf"`
+	Iterations int    `yaml:"iterations"`
+	Salt       string `yaml:"salt"`
+	Data       string `yaml:"data"`
+}
+
+// encryptedFile is a config file encrypted in config mode
+type encryptedFile struct {
+	Encryption *Encryption `yaml:"encryption"`
+}
+
+// cipherKey is a key derived from the passphrase with parameters of the config encryption
+type cipherKey struct {
+	encryption Encryption
+	aead       cipher.AEAD
+}
+
+// newCipherKey derives a key from the passphrase
+func newCipherKey(encryption Encryption, passphrase string) (*cipherKey, error) {
+	if encryption.KDF != kdfPBKDF2SHA256 {
+		return nil, fmt.Errorf("unsupported key derivation function %q", encryption.KDF)
+	}
+	salt, err := base64.StdEncoding.DecodeString(encryption.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, encryption.Iterations, keyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cipherKey{encryption: encryption, aead: aead}, nil
+}
+
+// newEncryption returns encryption parameters with a random salt
+func newEncryption(mode string) (Encryption, error) {
+	if mode != EncryptionModeConfig && mode != EncryptionModeTokens {
+		return Encryption{}, fmt.Errorf("unknown encryption mode %q", mode)
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return Encryption{}, err
+	}
+
+	return Encryption{
+		Mode:       mode,
+		KDF:        kdfPBKDF2SHA256,
+		Iterations: kdfIterations,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+	}, nil
+}
+
+// seal encrypts data with a random nonce prepended to the result
+func (k *cipherKey) seal(data []byte) (string, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(k.aead.Seal(nonce, nonce, data, nil)), nil
+}
+
+// open decrypts data encrypted by seal
+func (k *cipherKey) open(data string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil || len(raw) < k.aead.NonceSize() {
+		return nil, errors.New("invalid encrypted data")
+	}
+
+	nonceSize := k.aead.NonceSize()
+	plain, err := k.aead.Open(nil, raw[:nonceSize], raw[nonceSize:], nil)
+	if err != nil {
+		return nil, errors.New("wrong passphrase or corrupted config")
+	}
+	return plain, nil
+}
+
+// unlock derives the key from the passphrase and checks it by decrypting the data.
+// Returns decrypted data.
+func unlock(encryption Encryption, passphrase string) (*cipherKey, []byte, error) {
+	key, err := newCipherKey(encryption, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := key.open(encryption.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, data, nil
+}
+
+// encryptConfig returns content of the config file encrypted with the key
+func (k *cipherKey) encryptConfig(config Config) ([]byte, error) {
+	encryption := k.encryption
+
+	if encryption.Mode == EncryptionModeConfig {
+		data, err := yaml.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		if encryption.Data, err = k.seal(data); err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(encryptedFile{Encryption: &encryption})
+	}
+
+	var err error
+	if encryption.Data, err = k.seal([]byte(passphraseMarker)); err != nil {
+		return nil, err
+	}
+
+	contexts := make([]Context, len(config.Contexts))
+	for i, ctx := range config.Contexts {
+		if ctx.Token != "" {
+			token, err := k.seal([]byte(ctx.Token))
+			if err != nil {
+				return nil, err
+			}
+			ctx.Token = encryptedPrefix + token
+		}
+		contexts[i] = ctx
+	}
+	config.Contexts = contexts
+	config.Encryption = &encryption
+
+	return yaml.Marshal(config)
+}
+
+// decryptTokens decrypts tokens of contexts encrypted in tokens mode
+func (k *cipherKey) decryptTokens(config *Config) error {
+	for i := range config.Contexts {
+		ctx := &config.Contexts[i]
+		data, ok := strings.CutPrefix(ctx.Token, encryptedPrefix)
+		if !ok {
+			continue
+		}
+		token, err := k.open(data)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt token of context %q: %w", ctx.Name, err)
+		}
+		ctx.Token = string(token)
+	}
+	return nil
+}
+
+// ReadPassphrase returns the config passphrase from SRVCTL_PASSPHRASE env
+// or asks for it on a TTY, asking twice if confirm is set
+func ReadPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	stdin := int(os.Stdin.Fd())
+	if !term.IsTerminal(stdin) {
+		return "", fmt.Errorf("config is encrypted: set %s or run in a terminal to enter the passphrase", PassphraseEnv)
+	}
+
+	passphrase, err := promptPassword(stdin, "Enter config passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("passphrase cannot be empty")
+	}
+
+	if confirm {
+		repeated, err := promptPassword(stdin, "Repeat config passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if repeated != passphrase {
+			return "", errors.New("passphrases don't match")
+		}
+	}
+	return passphrase, nil
+}
+
+// promptPassword reads a password without echo, the prompt is printed to stderr to keep stdout clean
+func promptPassword(fd int, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return string(password), nil
+}
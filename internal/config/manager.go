@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"os"
@@ -15,6 +16,10 @@ import (
 type Manager struct {
 	config     *Config
 	configPath string
+	cacheDir   string
+	tokensDir  string
+	// key encrypts the config on save, nil if the config isn't encrypted
+	key *cipherKey
 }
 
 // NewManager creates a new Manager
@@ -29,6 +34,8 @@ func NewManager(configPath string) (*Manager, error) {
 
 	m := &Manager{
 		configPath: configPath,
+		cacheDir:   filepath.Join(filepath.Dir(configPath), "cache"),
+		tokensDir:  filepath.Join(filepath.Dir(configPath), "tokens"),
 	}
 
 	if err := m.Load(); err != nil {
@@ -52,6 +59,24 @@ func NewManagerWithConfig(config *Config) *Manager {
 	}
 }
 
+// GetCacheDir returns cache directory for the context.
+// Returns empty string if config is not loaded from a file, so there is no place for cache.
+func (m *Manager) GetCacheDir(contextName string) string {
+	if m.cacheDir == "" || contextName == "" {
+		return ""
+	}
+	return filepath.Join(m.cacheDir, contextName)
+}
+
+// GetTokenFilePath returns default path of the token file for the context.
+// Returns empty string if config is not loaded from a file.
+func (m *Manager) GetTokenFilePath(contextName string) string {
+	if m.tokensDir == "" || contextName == "" {
+		return ""
+	}
+	return filepath.Join(m.tokensDir, contextName)
+}
+
 // GetContexts returns all contexts from the config
 func (m *Manager) GetContexts() []Context {
 	if m.config == nil {
@@ -128,7 +153,29 @@ func (m *Manager) Load() error {
 		return m.Save()
 	}
 
-	if err := viper.ReadInConfig(); err != nil {
+	data, err := os.ReadFile(m.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	// encrypted configs are decrypted before viper reads them
+	var file encryptedFile
+	if err := yaml.Unmarshal(data, &file); err == nil && file.Encryption != nil {
+		passphrase, err := ReadPassphrase(false)
+		if err != nil {
+			return err
+		}
+		key, decrypted, err := unlock(*file.Encryption, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt config: %w", err)
+		}
+		if file.Encryption.Mode == EncryptionModeConfig {
+			data = decrypted
+		}
+		m.key = key
+	}
+
+	if err := viper.ReadConfig(bytes.NewReader(data)); err != nil {
 		return fmt.Errorf("failed to read config: %w", err)
 	}
 
@@ -137,6 +184,13 @@ func (m *Manager) Load() error {
 		return fmt.Errorf("failed to parse config: %w", err)
 	}
 
+	if m.key != nil {
+		if err := m.key.decryptTokens(&cfg); err != nil {
+			return err
+		}
+	}
+	cfg.Encryption = nil
+
 	m.config = &cfg
 	return nil
 }
@@ -147,13 +201,54 @@ func (m *Manager) Save() error {
 		return fmt.Errorf("no config loaded")
 	}
 
-	// marshal config via yaml since viper doesn't support case sensitive keys
-	data, err := yaml.Marshal(m.config)
+	var data []byte
+	var err error
+	if m.key != nil {
+		data, err = m.key.encryptConfig(*m.config)
+	} else {
+		// marshal config via yaml since viper doesn't support case sensitive keys
+		data, err = yaml.Marshal(m.config)
+	}
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(m.configPath, data, 0644)
+	// tokens may be stored in the config, so it's readable by the current user only
+	if err := os.WriteFile(m.configPath, data, 0600); err != nil {
+		return err
+	}
+	// WriteFile keeps permissions of an existing file
+	return os.Chmod(m.configPath, 0600)
+}
+
+// GetEncryptionMode returns encryption mode of the config, empty if it isn't encrypted
+func (m *Manager) GetEncryptionMode() string {
+	if m.key == nil {
+		return ""
+	}
+	return m.key.encryption.Mode
+}
+
+// Encrypt makes Save encrypt the config or its tokens, depending on mode, with the passphrase
+func (m *Manager) Encrypt(mode, passphrase string) error {
+	if passphrase == "" {
+		return fmt.Errorf("passphrase cannot be empty")
+	}
+	encryption, err := newEncryption(mode)
+	if err != nil {
+		return err
+	}
+	key, err := newCipherKey(encryption, passphrase)
+	if err != nil {
+		return err
+	}
+	m.key = key
+	return nil
+}
+
+// Decrypt makes Save write the config in plain text
+func (m *Manager) Decrypt() {
+	m.key = nil
 }
 
 // SetContext adds new context to config or updates existing one
@@ -218,24 +313,25 @@ func (m *Manager) UpdateContextConfig(contextName string, configOptions ConfigOp
 	return nil
 }
 
-// GetToken returns token for specified context or for default context if empty context passed
-func (m *Manager) GetToken(context string) string {
+// GetToken returns token for specified context or for default context if empty context passed.
+// Token is resolved on every call and never saved to the config, see Context.ResolveToken.
+func (m *Manager) GetToken(context string) (string, error) {
 	ctx := m.config.DefaultContext
 	if context != "" {
 		ctx = context
 	}
 
 	if ctx == "" && len(m.config.Contexts) > 0 {
-		return m.config.Contexts[0].Token
+		return m.config.Contexts[0].ResolveToken()
 	}
 
-	for _, c := range m.config.Contexts {
-		if c.Name == ctx {
-			return c.Token
+	for i := range m.config.Contexts {
+		if m.config.Contexts[i].Name == ctx {
+			return m.config.Contexts[i].ResolveToken()
 		}
 	}
 
-	return ""
+	return "", nil
 }
 
 // GetEndpoint returns endpoint for specified context or for default context if empty context passed
@@ -258,89 +354,43 @@ func (m *Manager) GetEndpoint(context string) string {
 	return ""
 }
 
-// GetConfigValue returns config value for default context or global value
-func (m *Manager) GetConfigValue(key string) any {
-	ctx := m.config.DefaultContext
-
-	if ctx == "" && len(m.config.Contexts) > 0 {
-		if v, ok := m.config.Contexts[0].Config[key]; ok {
-			return v
-		}
-		return nil
+// GetConfigValue returns config value for specified context or for default context if empty context passed,
+// or global value if the context doesn't set it
+func (m *Manager) GetConfigValue(context, key string) any {
+	if context == "" {
+		context = m.GetDefaultContextName()
 	}
 
-	for _, c := range m.config.Contexts {
-		if c.Name == ctx {
-			if v, ok := c.Config[key]; ok {
-				return v
-			}
+	if ctx, err := m.GetContext(context); err == nil {
+		if v, ok := ctx.Config[key]; ok {
+			return v
 		}
 	}
-	if v, ok := m.config.GlobalConfig[key]; ok {
+	if v, ok := m.GetGlobalConfig()[key]; ok {
 		return v
 	}
 
 	return nil
 }
 
-// GetResolvedStringValue returns resolved string value for a given config key.
-// By resolved means value is taken from command line flag if provided or else from config file or default value.
+// GetResolvedStringValue returns resolved string value for a given config key, see Resolve.
 func (m *Manager) GetResolvedStringValue(cmd *cobra.Command, flagName string) (string, error) {
-	if cmd.Flags().Changed(flagName) {
-		return cmd.Flags().GetString(flagName)
-	}
-	if configValue := m.GetConfigValue(flagName); configValue != nil {
-		if v, ok := configValue.(string); ok {
-			return v, nil
-		}
-		cmd.Printf("can't parse config value %q for %q as string, use default\n", configValue, flagName)
-	}
-	return cmd.Flags().GetString(flagName)
+	return resolveAs[string](m, cmd, flagName)
 }
 
-// GetResolvedIntValue returns resolved int value for a given config key.
-// By resolved means value is taken from command line flag if provided or else from config file or default value.
+// GetResolvedIntValue returns resolved int value for a given config key, see Resolve.
 func (m *Manager) GetResolvedIntValue(cmd *cobra.Command, flagName string) (int, error) {
-	if cmd.Flags().Changed(flagName) {
-		return cmd.Flags().GetInt(flagName)
-	}
-	if configValue := m.GetConfigValue(flagName); configValue != nil {
-		if v, ok := configValue.(int); ok {
-			return v, nil
-		}
-		cmd.Printf("can't parse config value %q for %q as int, use default\n", configValue, flagName)
-	}
-	return cmd.Flags().GetInt(flagName)
+	return resolveAs[int](m, cmd, flagName)
 }
 
-// GetResolvedBoolValue returns resolved bool value for a given config key.
-// By resolved means value is taken from command line flag if provided or else from config file or default value.
+// GetResolvedBoolValue returns resolved bool value for a given config key, see Resolve.
 func (m *Manager) GetResolvedBoolValue(cmd *cobra.Command, flagName string) (bool, error) {
-	if cmd.Flags().Changed(flagName) {
-		return cmd.Flags().GetBool(flagName)
-	}
-	if configValue := m.GetConfigValue(flagName); configValue != nil {
-		if v, ok := configValue.(bool); ok {
-			return v, nil
-		}
-		cmd.Printf("can't parse config value %q for %q as bool, use default\n", configValue, flagName)
-	}
-	return cmd.Flags().GetBool(flagName)
+	return resolveAs[bool](m, cmd, flagName)
 }
 
-// GetResolvedStringSliceValue returns resolved slice of string value for a given config key.
-// By resolved means value is taken from command line flag if provided or else from config file or default value.
+// GetResolvedStringSliceValue returns resolved slice of string value for a given config key, see Resolve.
 func (m *Manager) GetResolvedStringSliceValue(cmd *cobra.Command, flagName string) ([]string, error) {
-	if cmd.Flags().Changed(flagName) {
-		return cmd.Flags().GetStringArray(flagName)
-	}
-	if configValue := m.GetConfigValue(flagName); configValue != nil {
-		if v, ok := configValue.([]string); ok {
-			return v, nil
-		}
-		cmd.Printf("can't parse config value %q for %q as slice of string, use default\n", configValue, flagName)
-	}
-	return cmd.Flags().GetStringArray(flagName)
+	return resolveAs[[]string](m, cmd, flagName)
 }
 
 // GetVerbose reads verbose flag from cmd or from config
@@ -0,0 +1,267 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Config layers in order of precedence
+const (
+	LayerFlag    = "flag"
+	LayerEnv     = "env"
+	LayerContext = "context"
+	LayerGlobal  = "global"
+	LayerDefault = "default"
+)
+
+// EnvPrefix is a prefix of env vars overriding flags
+const EnvPrefix = "SRVCTL_"
+
+// EnvAnnotation is a flag annotation with the name of the env var overriding the flag, see BindEnv
+const EnvAnnotation = "srvctl_env"
+
+// EnvName returns name of the env var overriding the flag, e.g. SRVCTL_HTTP_TIMEOUT for http-timeout
+func EnvName(flagName string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// BindEnv makes named flags overridable by SRVCTL_<FLAG> env vars, see ApplyEnv
+func BindEnv(flags *pflag.FlagSet, names ...string) {
+	for _, name := range names {
+		_ = flags.SetAnnotation(name, EnvAnnotation, []string{EnvName(name)})
+	}
+}
+
+// ApplyEnv sets flags bound by BindEnv and not set on the command line to values of their env vars.
+// Flags are left unchanged, so Resolve reports env as the layer of their values.
+func ApplyEnv(flags *pflag.FlagSet) error {
+	var err error
+	flags.VisitAll(func(flag *pflag.Flag) {
+		envNames := flag.Annotations[EnvAnnotation]
+		if err != nil || flag.Changed || len(envNames) == 0 {
+			return
+		}
+		raw, ok := os.LookupEnv(envNames[0])
+		if !ok {
+			return
+		}
+
+		if sliceValue, isSlice := flag.Value.(pflag.SliceValue); isSlice {
+			var items []string
+			if raw != "" {
+				items = strings.Split(raw, ",")
+			}
+			err = sliceValue.Replace(items)
+		} else {
+			err = flag.Value.Set(raw)
+		}
+		if err != nil {
+			err = fmt.Errorf("invalid value of %s: %w", envNames[0], err)
+		}
+	})
+	return err
+}
+
+// ResolvedValue is a value of a config key set in one of the config layers.
+// Source is the flag, env var or context name the value is taken from.
+type ResolvedValue struct {
+	Layer  string `json:"layer" yaml:"layer"`
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	Value  any    `json:"value" yaml:"value"`
+}
+
+// Resolve returns value of the flag from the first layer setting it: the flag itself,
+// SRVCTL_<FLAG> env var, the selected context, the global config or the flag default.
+// Values of env vars and config are converted to the flag type.
+func (m *Manager) Resolve(cmd *cobra.Command, flagName string) (ResolvedValue, error) {
+	layers, err := m.resolveLayers(cmd, flagName, false)
+	if err != nil {
+		return ResolvedValue{}, err
+	}
+	return layers[0], nil
+}
+
+// ResolveLayers returns values of the flag in all layers setting it in order of precedence,
+// the first one is used. The default layer is always the last one.
+func (m *Manager) ResolveLayers(cmd *cobra.Command, flagName string) ([]ResolvedValue, error) {
+	return m.resolveLayers(cmd, flagName, true)
+}
+
+// resolveLayers collects values of the flag layer by layer, stopping at the first one unless all is set.
+// Invalid config values are reported and skipped, invalid env values are errors since they are set explicitly.
+func (m *Manager) resolveLayers(cmd *cobra.Command, flagName string, all bool) ([]ResolvedValue, error) {
+	flag := cmd.Flags().Lookup(flagName)
+	if flag == nil {
+		return nil, fmt.Errorf("flag accessed but not defined: %s", flagName)
+	}
+	flagType := flag.Value.Type()
+
+	var layers []ResolvedValue
+	done := func() bool { return !all && len(layers) > 0 }
+
+	if flag.Changed {
+		v, err := flagValue(cmd.Flags(), flag)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, ResolvedValue{Layer: LayerFlag, Source: "--" + flagName, Value: v})
+	}
+
+	if env := EnvName(flagName); !done() {
+		if raw, ok := os.LookupEnv(env); ok {
+			v, err := convertValue(raw, flagType)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of %s: %w", env, err)
+			}
+			layers = append(layers, ResolvedValue{Layer: LayerEnv, Source: env, Value: v})
+		}
+	}
+
+	if contextName := m.selectedContextName(cmd); !done() && contextName != "" {
+		if ctx, err := m.GetContext(contextName); err == nil {
+			if raw, ok := ctx.Config[flagName]; ok && raw != nil {
+				if v, err := convertValue(raw, flagType); err == nil {
+					layers = append(layers, ResolvedValue{Layer: LayerContext, Source: contextName, Value: v})
+				} else {
+					cmd.PrintErrf("can't use config value %v for %q of context %q: %v\n", raw, flagName, contextName, err)
+				}
+			}
+		}
+	}
+
+	if !done() {
+		if raw, ok := m.GetGlobalConfig()[flagName]; ok && raw != nil {
+			if v, err := convertValue(raw, flagType); err == nil {
+				layers = append(layers, ResolvedValue{Layer: LayerGlobal, Value: v})
+			} else {
+				cmd.PrintErrf("can't use global config value %v for %q: %v\n", raw, flagName, err)
+			}
+		}
+	}
+
+	if !done() {
+		// flag values may be set by ApplyEnv without marking them changed,
+		// so the default is read from the flag definition
+		layers = append(layers, ResolvedValue{Layer: LayerDefault, Value: defaultValue(flag)})
+	}
+
+	return layers, nil
+}
+
+// defaultValue returns typed default value of the flag
+func defaultValue(flag *pflag.Flag) any {
+	raw := flag.DefValue
+	flagType := flag.Value.Type()
+	if flagType == "stringArray" || flagType == "stringSlice" {
+		// lists are formatted as [a,b]
+		raw = strings.TrimSuffix(strings.TrimPrefix(raw, "["), "]")
+	}
+
+	v, err := convertValue(raw, flagType)
+	if err != nil {
+		return raw
+	}
+	return v
+}
+
+// selectedContextName returns the context selected by the 'context' flag or the default context
+func (m *Manager) selectedContextName(cmd *cobra.Command) string {
+	if name, err := cmd.Flags().GetString("context"); err == nil && name != "" {
+		return name
+	}
+	return m.GetDefaultContextName()
+}
+
+// flagValue returns typed value of the flag
+func flagValue(flags *pflag.FlagSet, flag *pflag.Flag) (any, error) {
+	switch flag.Value.Type() {
+	case "string":
+		return flags.GetString(flag.Name)
+	case "int":
+		return flags.GetInt(flag.Name)
+	case "bool":
+		return flags.GetBool(flag.Name)
+	case "stringArray":
+		return flags.GetStringArray(flag.Name)
+	case "stringSlice":
+		return flags.GetStringSlice(flag.Name)
+	default:
+		return flag.Value.String(), nil
+	}
+}
+
+// convertValue converts a value of env var or config to the flag type.
+// Strings are parsed, lists may be set as comma separated strings.
+func convertValue(v any, flagType string) (any, error) {
+	switch flagType {
+	case "string":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "int":
+		switch v := v.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			if v == math.Trunc(v) {
+				return int(v), nil
+			}
+		case string:
+			return strconv.Atoi(strings.TrimSpace(v))
+		}
+	case "bool":
+		switch v := v.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(strings.TrimSpace(v))
+		}
+	case "stringArray", "stringSlice":
+		switch v := v.(type) {
+		case []string:
+			return v, nil
+		case []any:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("expected list of strings, got %v", v)
+				}
+				items = append(items, s)
+			}
+			return items, nil
+		case string:
+			if v == "" {
+				return []string{}, nil
+			}
+			return strings.Split(v, ","), nil
+		}
+	default:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("expected %s, got %v", flagType, v)
+}
+
+// resolveAs returns resolved value of the flag of type T
+func resolveAs[T any](m *Manager, cmd *cobra.Command, flagName string) (T, error) {
+	var zero T
+	resolved, err := m.Resolve(cmd, flagName)
+	if err != nil {
+		return zero, err
+	}
+	v, ok := resolved.Value.(T)
+	if !ok {
+		return zero, fmt.Errorf("trying to get %T value of flag %q of type %T", zero, flagName, resolved.Value)
+	}
+	return v, nil
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// token stores of commands saving a token
+const (
+	TokenStoreConfig  = "config"
+	TokenStoreFile    = "file"
+	TokenStoreCommand = "command"
+)
+
+// DefaultTokenStore keeps tokens out of the config file, so it can be shared
+// or exported without them
+const DefaultTokenStore = TokenStoreFile
+
+// ValidateTokenStore checks if store is one of stores supported by a command
+func ValidateTokenStore(store string, stores ...string) error {
+	if !slices.Contains(stores, store) {
+		return fmt.Errorf("invalid store %q, allowed values: %s", store, strings.Join(stores, ", "))
+	}
+	return nil
+}
+
+// ResolveToken returns the token of the context. It's read from TokenFile or
+// printed to stdout by TokenCommand if one of them is set, so the token isn't
+// stored in the config file. The token is resolved on every call.
+func (c *Context) ResolveToken() (string, error) {
+	sources := 0
+	for _, s := range []string{c.Token, c.TokenFile, c.TokenCommand} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return "", fmt.Errorf("context %q has more than one of token, tokenFile and tokenCommand", c.Name)
+	}
+
+	switch {
+	case c.TokenCommand != "":
+		token, err := runTokenCommand(c.TokenCommand)
+		if err != nil {
+			return "", fmt.Errorf("failed to get token of context %q: %w", c.Name, err)
+		}
+		return token, nil
+	case c.TokenFile != "":
+		token, err := readTokenFile(c.TokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to get token of context %q: %w", c.Name, err)
+		}
+		return token, nil
+	default:
+		return c.Token, nil
+	}
+}
+
+// runTokenCommand runs command in a shell and returns its trimmed stdout.
+// Stdin and stderr are passed through, so helpers may ask for a passphrase.
+func runTokenCommand(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	var stdout bytes.Buffer
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("token command %q failed: %w", command, err)
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("token command %q printed an empty token", command)
+	}
+	return token, nil
+}
+
+// readTokenFile returns trimmed content of the token file, ~ is expanded to the home dir
+func readTokenFile(path string) (string, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %q is empty", path)
+	}
+	return token, nil
+}
+
+// WriteTokenFile writes token to a file readable by the current user only
+func WriteTokenFile(path, token string) error {
+	path, err := expandHome(path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create token directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		return err
+	}
+	// WriteFile keeps permissions of an existing file
+	return os.Chmod(path, 0600)
+}
+
+// expandHome replaces leading ~ of the path with the home dir
+func expandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~")
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.New("failed to expand ~: home directory is unknown")
+	}
+	return filepath.Join(home, rest), nil
+}
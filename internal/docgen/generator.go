@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/cpuguy83/go-md2man/v2/md2man"
+	"github.com/serverscom/srvctl/internal/config"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -260,9 +261,16 @@ func (g *Generator) formatFlags(buf *bytes.Buffer, flags *pflag.FlagSet) {
 		if flag.Usage != "" {
 			fmt.Fprintf(buf, "        %s", flag.Usage)
 		}
-		if flag.DefValue != "" && flag.DefValue != "false" && flag.DefValue != "[]" && flag.DefValue != "0" {
+		hasDefault := flag.DefValue != "" && flag.DefValue != "false" && flag.DefValue != "[]" && flag.DefValue != "0"
+		if hasDefault {
 			fmt.Fprintf(buf, ". The default is `%s`.", flag.DefValue)
 		}
+		if envNames := flag.Annotations[config.EnvAnnotation]; len(envNames) > 0 {
+			if !hasDefault {
+				buf.WriteString(".")
+			}
+			fmt.Fprintf(buf, " Can be set with the `%s` environment variable.", envNames[0])
+		}
 		buf.WriteString("\n\n")
 	})
 }
@@ -0,0 +1,131 @@
+package inventory
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Action represents a difference of a resource between two snapshots
+type Action string
+
+const (
+	ActionAdded   Action = "added"
+	ActionRemoved Action = "removed"
+	ActionChanged Action = "changed"
+)
+
+// ignoredFields are not compared, they change along with other fields
+var ignoredFields = []string{"updated_at"}
+
+// FieldChange represents a change of a single field
+type FieldChange struct {
+	Field string `json:"field" yaml:"field"`
+	Old   any    `json:"old" yaml:"old"`
+	New   any    `json:"new" yaml:"new"`
+}
+
+// Change represents a resource added, removed or changed between two snapshots
+type Change struct {
+	Kind   string        `json:"kind" yaml:"kind"`
+	ID     string        `json:"id" yaml:"id"`
+	Name   string        `json:"name,omitempty" yaml:"name,omitempty"`
+	Action Action        `json:"action" yaml:"action"`
+	Fields []FieldChange `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// String returns human readable resource identifier
+func (c *Change) String() string {
+	return fmt.Sprintf("%s/%s", c.Kind, c.ID)
+}
+
+// Diff returns resources added, removed and changed in the after snapshot
+// in comparison to the before one. Resources are matched by kind and identifier.
+func Diff(before, after *Snapshot) []Change {
+	var kinds []string
+	for kind := range before.Resources {
+		kinds = append(kinds, kind)
+	}
+	for kind := range after.Resources {
+		if !slices.Contains(kinds, kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	slices.Sort(kinds)
+
+	var result []Change
+	for _, kind := range kinds {
+		oldItems := indexByID(kind, before.Resources[kind])
+		newItems := indexByID(kind, after.Resources[kind])
+
+		ids := make([]string, 0, len(oldItems)+len(newItems))
+		for id := range oldItems {
+			ids = append(ids, id)
+		}
+		for id := range newItems {
+			if _, ok := oldItems[id]; !ok {
+				ids = append(ids, id)
+			}
+		}
+		slices.Sort(ids)
+
+		for _, id := range ids {
+			oldItem, inOld := oldItems[id]
+			newItem, inNew := newItems[id]
+
+			change := Change{Kind: kind, ID: id}
+			switch {
+			case !inOld:
+				change.Action = ActionAdded
+				change.Name = resourceName(newItem)
+			case !inNew:
+				change.Action = ActionRemoved
+				change.Name = resourceName(oldItem)
+			default:
+				change.Fields = diffFields(oldItem, newItem)
+				if len(change.Fields) == 0 {
+					continue
+				}
+				change.Action = ActionChanged
+				change.Name = resourceName(newItem)
+			}
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+// indexByID returns resources by their identifiers
+func indexByID(kind string, items []map[string]any) map[string]map[string]any {
+	result := make(map[string]map[string]any, len(items))
+	for _, item := range items {
+		result[resourceID(kind, item)] = item
+	}
+	return result
+}
+
+// diffFields returns top level fields which differ between two versions
+// of a resource
+func diffFields(before, after map[string]any) []FieldChange {
+	var keys []string
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var result []FieldChange
+	for _, key := range keys {
+		if slices.Contains(ignoredFields, key) {
+			continue
+		}
+		if !reflect.DeepEqual(before[key], after[key]) {
+			result = append(result, FieldChange{Field: key, Old: before[key], New: after[key]})
+		}
+	}
+	return result
+}
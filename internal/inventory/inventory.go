@@ -0,0 +1,183 @@
+// Package inventory takes snapshots of all resources of an account and
+// compares them.
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"gopkg.in/yaml.v3"
+)
+
+// SnapshotVersion is a version of the snapshot format written by Export
+const SnapshotVersion = 1
+
+// Snapshot contains all resources of an account grouped by kind.
+// Resources are API objects sorted by their identifiers.
+type Snapshot struct {
+	Version   int                         `json:"version" yaml:"version"`
+	CreatedAt time.Time                   `json:"created_at" yaml:"created_at"`
+	Resources map[string][]map[string]any `json:"resources" yaml:"resources"`
+}
+
+// ExportOptions holds options of export
+type ExportOptions struct {
+	// Parallel is a number of collections listed at the same time
+	Parallel int
+}
+
+// Export lists resources of all kinds. Child resources, like networks of hosts,
+// are listed after their parents.
+func Export(ctx context.Context, client *serverscom.Client, opts ExportOptions) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Version:   SnapshotVersion,
+		CreatedAt: time.Now().UTC(),
+		Resources: make(map[string][]map[string]any),
+	}
+
+	parents := make([][]map[string]any, len(sources))
+	err := run(ctx, opts.Parallel, len(sources), func(ctx context.Context, i int) error {
+		items, err := sources[i].list(ctx, client)
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", sources[i].kind, err)
+		}
+		parents[i] = items
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range sources {
+		snapshot.Resources[s.kind] = parents[i]
+	}
+
+	// one task per parent resource of each child kind
+	type task struct {
+		child    child
+		parentID string
+	}
+	var tasks []task
+	for _, c := range children {
+		for _, item := range snapshot.Resources[c.parent] {
+			tasks = append(tasks, task{child: c, parentID: resourceID(c.parent, item)})
+		}
+	}
+
+	results := make([][]map[string]any, len(tasks))
+	err = run(ctx, opts.Parallel, len(tasks), func(ctx context.Context, i int) error {
+		t := tasks[i]
+		items, err := t.child.list(ctx, client, t.parentID)
+		if err != nil {
+			return fmt.Errorf("failed to list %s of %s/%s: %w", t.child.kind, t.child.parent, t.parentID, err)
+		}
+		for _, item := range items {
+			item["parent"] = fmt.Sprintf("%s/%s", t.child.parent, t.parentID)
+		}
+		results[i] = items
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range children {
+		if _, ok := snapshot.Resources[c.kind]; !ok {
+			snapshot.Resources[c.kind] = []map[string]any{}
+		}
+	}
+	for i, t := range tasks {
+		snapshot.Resources[t.child.kind] = append(snapshot.Resources[t.child.kind], results[i]...)
+	}
+
+	for kind, items := range snapshot.Resources {
+		slices.SortStableFunc(items, func(a, b map[string]any) int {
+			return strings.Compare(resourceID(kind, a), resourceID(kind, b))
+		})
+	}
+	return snapshot, nil
+}
+
+// run calls fn for indexes from 0 to n by up to parallel workers.
+// The first error cancels calls in flight and is returned.
+func run(ctx context.Context, parallel, n int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		next int
+	)
+	for range min(max(parallel, 1), n) {
+		wg.Go(func() {
+			for {
+				mu.Lock()
+				i := next
+				next++
+				mu.Unlock()
+				if i >= n || ctx.Err() != nil {
+					return
+				}
+
+				if err := fn(ctx, i); err != nil {
+					cancel(err)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	return context.Cause(ctx)
+}
+
+// Parse parses a JSON or YAML snapshot. YAML is converted to JSON first,
+// so values are decoded the same way regardless of the format.
+func Parse(data []byte, source string) (*Snapshot, error) {
+	raw := bytes.TrimSpace(data)
+	if len(raw) == 0 || raw[0] != '{' {
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%s: could not parse snapshot: %w", source, err)
+		}
+		var err error
+		if raw, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("%s: could not parse snapshot: %w", source, err)
+		}
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, fmt.Errorf("%s: could not parse snapshot: %w", source, err)
+	}
+	if snapshot.Version < 1 || snapshot.Version > SnapshotVersion {
+		return nil, fmt.Errorf("%s: unsupported snapshot version %d, the latest supported version is %d", source, snapshot.Version, SnapshotVersion)
+	}
+	return &snapshot, nil
+}
+
+// ReadFile reads a snapshot from the file, '-' reads it from in
+func ReadFile(path string, in io.Reader) (*Snapshot, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(in)
+		path = "stdin"
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	return Parse(data, path)
+}
@@ -0,0 +1,101 @@
+package inventory
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDiff(t *testing.T) {
+	before := &Snapshot{
+		Version: SnapshotVersion,
+		Resources: map[string][]map[string]any{
+			"ebm": {
+				{"id": "h1", "title": "web-01", "status": "active", "updated_at": "2025-01-01T00:00:00Z"},
+				{"id": "h2", "title": "db-01", "status": "active"},
+			},
+			"ssh-keys": {
+				{"name": "deploy", "fingerprint": "aa:bb", "labels": map[string]any{"env": "prod"}},
+			},
+		},
+	}
+	after := &Snapshot{
+		Version: SnapshotVersion,
+		Resources: map[string][]map[string]any{
+			"ebm": {
+				{"id": "h1", "title": "web-01", "status": "active", "updated_at": "2025-02-01T00:00:00Z"},
+				{"id": "h3", "title": "db-02", "status": "init"},
+			},
+			"ssh-keys": {
+				{"name": "deploy", "fingerprint": "aa:bb", "labels": map[string]any{"env": "stage"}},
+			},
+			"l2-segments": {
+				{"id": "s1", "name": "private"},
+			},
+		},
+	}
+
+	g := NewWithT(t)
+
+	changes := Diff(before, after)
+	g.Expect(changes).To(Equal([]Change{
+		{Kind: "ebm", ID: "h2", Name: "db-01", Action: ActionRemoved},
+		{Kind: "ebm", ID: "h3", Name: "db-02", Action: ActionAdded},
+		{Kind: "l2-segments", ID: "s1", Name: "private", Action: ActionAdded},
+		{Kind: "ssh-keys", ID: "aa:bb", Name: "deploy", Action: ActionChanged, Fields: []FieldChange{
+			{Field: "labels", Old: map[string]any{"env": "prod"}, New: map[string]any{"env": "stage"}},
+		}},
+	}))
+	g.Expect(Diff(before, before)).To(BeEmpty())
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name        string
+		data        string
+		expectError bool
+	}{
+		{
+			name: "json",
+			data: `{"version": 1, "created_at": "2025-01-01T00:00:00Z", "resources": {"ebm": [{"id": "h1", "location_id": 1}]}}`,
+		},
+		{
+			name: "yaml",
+			data: "version: 1\ncreated_at: 2025-01-01T00:00:00Z\nresources:\n  ebm:\n    - id: h1\n      location_id: 1\n",
+		},
+		{
+			name:        "unsupported version",
+			data:        `{"version": 2, "resources": {}}`,
+			expectError: true,
+		},
+		{
+			name:        "no version",
+			data:        "resources: {}\n",
+			expectError: true,
+		},
+		{
+			name:        "invalid",
+			data:        "{",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			snapshot, err := Parse([]byte(tc.data), "test")
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).To(BeNil())
+			// numbers are decoded as in JSON regardless of the format
+			g.Expect(snapshot.Resources).To(Equal(map[string][]map[string]any{
+				"ebm": {{"id": "h1", "location_id": float64(1)}},
+			}))
+			g.Expect(snapshot.CreatedAt.Year()).To(Equal(2025))
+		})
+	}
+}
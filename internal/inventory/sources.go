@@ -0,0 +1,170 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+)
+
+// source lists all resources of a snapshot kind
+type source struct {
+	kind string
+	list func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error)
+}
+
+// child lists resources which belong to resources of a parent kind,
+// e.g. networks of hosts
+type child struct {
+	kind   string
+	parent string
+	list   func(ctx context.Context, client *serverscom.Client, parentID string) ([]map[string]any, error)
+}
+
+// sources contains top level kinds, names match srvctl commands
+var sources = []source{
+	{kind: "ebm", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.Hosts.ListDedicatedServers())
+	}},
+	{kind: "kbm", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.Hosts.ListKubernetesBaremetalNodes())
+	}},
+	{kind: "sbm", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.Hosts.ListSBMServers())
+	}},
+	{kind: "l2-segments", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.L2Segments.Collection())
+	}},
+	{kind: "network-pools", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.NetworkPools.Collection())
+	}},
+	{kind: "lb", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.LoadBalancers.Collection())
+	}},
+	{kind: "ssl", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.SSLCertificates.Collection())
+	}},
+	{kind: "cloud-instances", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.CloudComputingInstances.Collection())
+	}},
+	{kind: "cloud-volumes", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.CloudBlockStorageVolumes.Collection())
+	}},
+	{kind: "cloud-backups", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.CloudBlockStorageBackups.Collection())
+	}},
+	{kind: "rbs", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.RemoteBlockStorageVolumes.Collection())
+	}},
+	{kind: "k8s", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.KubernetesClusters.Collection())
+	}},
+	{kind: "ssh-keys", list: func(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+		return collect(ctx, client.SSHKeys.Collection())
+	}},
+}
+
+// children contains kinds listed per parent resource, resources of the same
+// kind are merged and refer to their parent by the parent field
+var children = []child{
+	{kind: "networks", parent: "ebm", list: func(ctx context.Context, client *serverscom.Client, id string) ([]map[string]any, error) {
+		return collect(ctx, client.Hosts.DedicatedServerNetworks(id))
+	}},
+	{kind: "networks", parent: "kbm", list: func(ctx context.Context, client *serverscom.Client, id string) ([]map[string]any, error) {
+		return collect(ctx, client.Hosts.KubernetesBaremetalNodeNetworks(id))
+	}},
+	{kind: "networks", parent: "sbm", list: func(ctx context.Context, client *serverscom.Client, id string) ([]map[string]any, error) {
+		return collect(ctx, client.Hosts.SBMServerNetworks(id))
+	}},
+	{kind: "ptr", parent: "ebm", list: func(ctx context.Context, client *serverscom.Client, id string) ([]map[string]any, error) {
+		return collect(ctx, client.Hosts.DedicatedServerPTRRecords(id))
+	}},
+	{kind: "ptr", parent: "sbm", list: func(ctx context.Context, client *serverscom.Client, id string) ([]map[string]any, error) {
+		return collect(ctx, client.Hosts.SBMServerPTRRecords(id))
+	}},
+	{kind: "ptr", parent: "cloud-instances", list: func(ctx context.Context, client *serverscom.Client, id string) ([]map[string]any, error) {
+		return collect(ctx, client.CloudComputingInstances.PTRRecords(id))
+	}},
+}
+
+// idKeys contains keys of resource identifiers which differ from id
+var idKeys = map[string]string{
+	"ssh-keys": "fingerprint",
+}
+
+// nameKeys are keys of a human readable resource name in order of preference
+var nameKeys = []string{"name", "title", "domain"}
+
+// KindNames returns names of all snapshot kinds in the order they are listed
+func KindNames() []string {
+	var names []string
+	for _, s := range sources {
+		names = append(names, s.kind)
+	}
+	for _, c := range children {
+		if len(names) == 0 || names[len(names)-1] != c.kind {
+			names = append(names, c.kind)
+		}
+	}
+	return names
+}
+
+// idKey returns the key of the resource identifier of the kind
+func idKey(kind string) string {
+	if key, ok := idKeys[kind]; ok {
+		return key
+	}
+	return "id"
+}
+
+// resourceID returns the identifier of the resource
+func resourceID(kind string, item map[string]any) string {
+	v, ok := item[idKey(kind)]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// resourceName returns a human readable name of the resource if it has one
+func resourceName(item map[string]any) string {
+	for _, key := range nameKeys {
+		if s, ok := item[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// collect fetches all items of the collection converted to JSON objects
+func collect[T any](ctx context.Context, collection serverscom.Collection[T]) ([]map[string]any, error) {
+	items, err := collection.Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		m, err := toMap(item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+// toMap converts v to JSON object
+func toMap(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
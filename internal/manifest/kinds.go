@@ -0,0 +1,280 @@
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+)
+
+// Kind describes how resources of a manifest kind are listed, created and updated
+type Kind interface {
+	// List returns all existing resources converted to JSON objects
+	List(ctx context.Context, client *serverscom.Client) ([]map[string]any, error)
+	// Create creates a resource from spec
+	Create(ctx context.Context, client *serverscom.Client, spec map[string]any) (map[string]any, error)
+	// Update updates a resource with the updatable part of spec
+	Update(ctx context.Context, client *serverscom.Client, id string, spec map[string]any) (map[string]any, error)
+	// Delete deletes a resource
+	Delete(ctx context.Context, client *serverscom.Client, id string) error
+	// UpdatableSpec returns the part of spec which can be passed to update
+	UpdatableSpec(spec map[string]any) (map[string]any, error)
+	// IDKey returns the key of the resource identifier
+	IDKey() string
+}
+
+// kind is a generic Kind implementation, where T is a type of listed
+// resources, C is a create input type and U is an update input type
+type kind[T, C, U any] struct {
+	idKey      string
+	collection func(client *serverscom.Client) serverscom.Collection[T]
+	create     func(ctx context.Context, client *serverscom.Client, input C) (any, error)
+	update     func(ctx context.Context, client *serverscom.Client, id string, input U) (any, error)
+	delete     func(ctx context.Context, client *serverscom.Client, id string) error
+}
+
+func (k *kind[T, C, U]) IDKey() string {
+	if k.idKey == "" {
+		return "id"
+	}
+	return k.idKey
+}
+
+func (k *kind[T, C, U]) List(ctx context.Context, client *serverscom.Client) ([]map[string]any, error) {
+	items, err := k.collection(client).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		m, err := toMap(item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+func (k *kind[T, C, U]) Create(ctx context.Context, client *serverscom.Client, spec map[string]any) (map[string]any, error) {
+	var input C
+	if err := fromMap(spec, &input, true); err != nil {
+		return nil, err
+	}
+
+	v, err := k.create(ctx, client, input)
+	if err != nil {
+		return nil, err
+	}
+	return toMap(v)
+}
+
+func (k *kind[T, C, U]) Update(ctx context.Context, client *serverscom.Client, id string, spec map[string]any) (map[string]any, error) {
+	var input U
+	if err := fromMap(spec, &input, false); err != nil {
+		return nil, err
+	}
+
+	v, err := k.update(ctx, client, id, input)
+	if err != nil {
+		return nil, err
+	}
+	return toMap(v)
+}
+
+func (k *kind[T, C, U]) Delete(ctx context.Context, client *serverscom.Client, id string) error {
+	return k.delete(ctx, client, id)
+}
+
+func (k *kind[T, C, U]) UpdatableSpec(spec map[string]any) (map[string]any, error) {
+	var input U
+	if err := fromMap(spec, &input, false); err != nil {
+		return nil, err
+	}
+	updatable, err := toMap(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep only fields which are set in spec
+	result := make(map[string]any)
+	for key := range updatable {
+		if v, ok := spec[key]; ok {
+			result[key] = v
+		}
+	}
+	return result, nil
+}
+
+// kinds contains all supported manifest kinds, names match srvctl commands
+var kinds = map[string]Kind{
+	"ssh-keys": &kind[serverscom.SSHKey, serverscom.SSHKeyCreateInput, serverscom.SSHKeyUpdateInput]{
+		idKey: "fingerprint",
+		collection: func(client *serverscom.Client) serverscom.Collection[serverscom.SSHKey] {
+			return client.SSHKeys.Collection()
+		},
+		create: func(ctx context.Context, client *serverscom.Client, input serverscom.SSHKeyCreateInput) (any, error) {
+			return client.SSHKeys.Create(ctx, input)
+		},
+		update: func(ctx context.Context, client *serverscom.Client, id string, input serverscom.SSHKeyUpdateInput) (any, error) {
+			return client.SSHKeys.Update(ctx, id, input)
+		},
+		delete: func(ctx context.Context, client *serverscom.Client, id string) error {
+			return client.SSHKeys.Delete(ctx, id)
+		},
+	},
+	"ssl-custom": &kind[serverscom.SSLCertificate, serverscom.SSLCertificateCreateCustomInput, serverscom.SSLCertificateUpdateCustomInput]{
+		collection: func(client *serverscom.Client) serverscom.Collection[serverscom.SSLCertificate] {
+			return client.SSLCertificates.Collection().SetParam("type", "custom")
+		},
+		create: func(ctx context.Context, client *serverscom.Client, input serverscom.SSLCertificateCreateCustomInput) (any, error) {
+			return client.SSLCertificates.CreateCustom(ctx, input)
+		},
+		update: func(ctx context.Context, client *serverscom.Client, id string, input serverscom.SSLCertificateUpdateCustomInput) (any, error) {
+			return client.SSLCertificates.UpdateCustom(ctx, id, input)
+		},
+		delete: func(ctx context.Context, client *serverscom.Client, id string) error {
+			return client.SSLCertificates.DeleteCustom(ctx, id)
+		},
+	},
+	"l2-segments": &kind[serverscom.L2Segment, serverscom.L2SegmentCreateInput, serverscom.L2SegmentUpdateInput]{
+		collection: func(client *serverscom.Client) serverscom.Collection[serverscom.L2Segment] {
+			return client.L2Segments.Collection()
+		},
+		create: func(ctx context.Context, client *serverscom.Client, input serverscom.L2SegmentCreateInput) (any, error) {
+			return client.L2Segments.Create(ctx, input)
+		},
+		update: func(ctx context.Context, client *serverscom.Client, id string, input serverscom.L2SegmentUpdateInput) (any, error) {
+			return client.L2Segments.Update(ctx, id, input)
+		},
+		delete: func(ctx context.Context, client *serverscom.Client, id string) error {
+			return client.L2Segments.Delete(ctx, id)
+		},
+	},
+	"lb-l4": &kind[serverscom.LoadBalancer, serverscom.L4LoadBalancerCreateInput, serverscom.L4LoadBalancerUpdateInput]{
+		collection: func(client *serverscom.Client) serverscom.Collection[serverscom.LoadBalancer] {
+			return client.LoadBalancers.Collection().SetParam("type", "l4")
+		},
+		create: func(ctx context.Context, client *serverscom.Client, input serverscom.L4LoadBalancerCreateInput) (any, error) {
+			return client.LoadBalancers.CreateL4LoadBalancer(ctx, input)
+		},
+		update: func(ctx context.Context, client *serverscom.Client, id string, input serverscom.L4LoadBalancerUpdateInput) (any, error) {
+			return client.LoadBalancers.UpdateL4LoadBalancer(ctx, id, input)
+		},
+		delete: func(ctx context.Context, client *serverscom.Client, id string) error {
+			return client.LoadBalancers.DeleteL4LoadBalancer(ctx, id)
+		},
+	},
+	"lb-l7": &kind[serverscom.LoadBalancer, serverscom.L7LoadBalancerCreateInput, serverscom.L7LoadBalancerUpdateInput]{
+		collection: func(client *serverscom.Client) serverscom.Collection[serverscom.LoadBalancer] {
+			return client.LoadBalancers.Collection().SetParam("type", "l7")
+		},
+		create: func(ctx context.Context, client *serverscom.Client, input serverscom.L7LoadBalancerCreateInput) (any, error) {
+			return client.LoadBalancers.CreateL7LoadBalancer(ctx, input)
+		},
+		update: func(ctx context.Context, client *serverscom.Client, id string, input serverscom.L7LoadBalancerUpdateInput) (any, error) {
+			return client.LoadBalancers.UpdateL7LoadBalancer(ctx, id, input)
+		},
+		delete: func(ctx context.Context, client *serverscom.Client, id string) error {
+			return client.LoadBalancers.DeleteL7LoadBalancer(ctx, id)
+		},
+	},
+	"cloud-instances": &kind[serverscom.CloudComputingInstance, serverscom.CloudComputingInstanceCreateInput, serverscom.CloudComputingInstanceUpdateInput]{
+		collection: func(client *serverscom.Client) serverscom.Collection[serverscom.CloudComputingInstance] {
+			return client.CloudComputingInstances.Collection()
+		},
+		create: func(ctx context.Context, client *serverscom.Client, input serverscom.CloudComputingInstanceCreateInput) (any, error) {
+			return client.CloudComputingInstances.Create(ctx, input)
+		},
+		update: func(ctx context.Context, client *serverscom.Client, id string, input serverscom.CloudComputingInstanceUpdateInput) (any, error) {
+			return client.CloudComputingInstances.Update(ctx, id, input)
+		},
+		delete: func(ctx context.Context, client *serverscom.Client, id string) error {
+			return client.CloudComputingInstances.Delete(ctx, id)
+		},
+	},
+	"cloud-volumes": &kind[serverscom.CloudBlockStorageVolume, serverscom.CloudBlockStorageVolumeCreateInput, serverscom.CloudBlockStorageVolumeUpdateInput]{
+		collection: func(client *serverscom.Client) serverscom.Collection[serverscom.CloudBlockStorageVolume] {
+			return client.CloudBlockStorageVolumes.Collection()
+		},
+		create: func(ctx context.Context, client *serverscom.Client, input serverscom.CloudBlockStorageVolumeCreateInput) (any, error) {
+			return client.CloudBlockStorageVolumes.Create(ctx, input)
+		},
+		update: func(ctx context.Context, client *serverscom.Client, id string, input serverscom.CloudBlockStorageVolumeUpdateInput) (any, error) {
+			return client.CloudBlockStorageVolumes.Update(ctx, id, input)
+		},
+		delete: func(ctx context.Context, client *serverscom.Client, id string) error {
+			_, err := client.CloudBlockStorageVolumes.Delete(ctx, id)
+			return err
+		},
+	},
+	"rbs": &kind[serverscom.RemoteBlockStorageVolume, serverscom.RemoteBlockStorageVolumeCreateInput, serverscom.RemoteBlockStorageVolumeUpdateInput]{
+		collection: func(client *serverscom.Client) serverscom.Collection[serverscom.RemoteBlockStorageVolume] {
+			return client.RemoteBlockStorageVolumes.Collection()
+		},
+		create: func(ctx context.Context, client *serverscom.Client, input serverscom.RemoteBlockStorageVolumeCreateInput) (any, error) {
+			return client.RemoteBlockStorageVolumes.Create(ctx, input)
+		},
+		update: func(ctx context.Context, client *serverscom.Client, id string, input serverscom.RemoteBlockStorageVolumeUpdateInput) (any, error) {
+			return client.RemoteBlockStorageVolumes.Update(ctx, id, input)
+		},
+		delete: func(ctx context.Context, client *serverscom.Client, id string) error {
+			return client.RemoteBlockStorageVolumes.Delete(ctx, id)
+		},
+	},
+}
+
+// GetKind returns manifest kind by its name
+func GetKind(name string) (Kind, error) {
+	k, ok := kinds[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown kind %q, supported kinds: %s", name, strings.Join(KindNames(), ", "))
+	}
+	return k, nil
+}
+
+// KindNames returns sorted names of supported kinds
+func KindNames() []string {
+	names := make([]string, 0, len(kinds))
+	for name := range kinds {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// toMap converts v to JSON object
+func toMap(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// fromMap converts JSON object to v, strict mode disallows unknown fields
+func fromMap(m map[string]any, v any, strict bool) error {
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid spec: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// manifestExtensions are file extensions which are read from directories
+var manifestExtensions = []string{".yaml", ".yml", ".json"}
+
+// Resource represents a single resource described in a manifest.
+// Spec is a request body of the create API call, the same as for
+// the --input flag of the corresponding add command.
+type Resource struct {
+	Kind       string         `json:"kind"`
+	MatchLabel string         `json:"matchLabel,omitempty"`
+	Spec       map[string]any `json:"spec"`
+	// Source points to the file and document the resource was read from
+	Source string `json:"-"`
+}
+
+// GetName returns the name of the resource from spec
+func (r *Resource) GetName() string {
+	name, _ := r.Spec["name"].(string)
+	return name
+}
+
+// GetMatchLabelValue returns the value of the match label from spec labels
+func (r *Resource) GetMatchLabelValue() (string, bool) {
+	labels, _ := r.Spec["labels"].(map[string]any)
+	v, ok := labels[r.MatchLabel]
+	if !ok {
+		return "", false
+	}
+	s, ok := v.(string)
+	return s, ok
+}
+
+// Ref returns the name or the match label the resource is matched by
+func (r *Resource) Ref() string {
+	if r.MatchLabel != "" {
+		v, _ := r.GetMatchLabelValue()
+		return fmt.Sprintf("%s=%s", r.MatchLabel, v)
+	}
+	return r.GetName()
+}
+
+// String returns human readable resource identifier
+func (r *Resource) String() string {
+	return fmt.Sprintf("%s/%s", r.Kind, r.Ref())
+}
+
+// Validate checks that resource has all required fields
+func (r *Resource) Validate() error {
+	if r.Kind == "" {
+		return fmt.Errorf("%s: kind is required", r.Source)
+	}
+	if len(r.Spec) == 0 {
+		return fmt.Errorf("%s: spec is required", r.Source)
+	}
+	if r.MatchLabel != "" {
+		if _, ok := r.GetMatchLabelValue(); !ok {
+			return fmt.Errorf("%s: spec.labels should contain %q match label", r.Source, r.MatchLabel)
+		}
+		return nil
+	}
+	if r.GetName() == "" {
+		return fmt.Errorf("%s: spec.name or matchLabel is required", r.Source)
+	}
+	return nil
+}
+
+// ReadFiles reads resources from manifest files. Directories are read
+// non-recursively, '-' path means stdin.
+func ReadFiles(paths []string, in io.Reader) ([]Resource, error) {
+	var result []Resource
+
+	for _, path := range paths {
+		files := []string{path}
+		if path != "-" {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, err
+			}
+			if info.IsDir() {
+				if files, err = listManifestFiles(path); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		for _, file := range files {
+			resources, err := readFile(file, in)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, resources...)
+		}
+	}
+
+	return result, nil
+}
+
+// listManifestFiles returns sorted manifest files from directory
+func listManifestFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || !slices.Contains(manifestExtensions, strings.ToLower(filepath.Ext(e.Name()))) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, e.Name()))
+	}
+	return files, nil
+}
+
+func readFile(path string, in io.Reader) ([]Resource, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(in)
+		path = "stdin"
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	return Parse(data, path)
+}
+
+// Parse parses multi-document YAML or JSON manifest. A document can contain
+// a single resource or a list of resources.
+func Parse(data []byte, source string) ([]Resource, error) {
+	docs, err := decodeDocuments(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: could not parse manifest: %w", source, err)
+	}
+
+	var result []Resource
+	for i, doc := range docs {
+		items := []any{doc}
+		list, isList := doc.([]any)
+		if isList {
+			items = list
+		}
+
+		for j, item := range items {
+			src := fmt.Sprintf("%s[%d]", source, i)
+			if isList {
+				src = fmt.Sprintf("%s[%d][%d]", source, i, j)
+			}
+
+			r, err := toResource(item)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", src, err)
+			}
+			r.Source = src
+			if err := r.Validate(); err != nil {
+				return nil, err
+			}
+			result = append(result, r)
+		}
+	}
+
+	return result, nil
+}
+
+// decodeDocuments decodes all documents from data. JSON is decoded as
+// a stream of values, everything else as multi-document YAML.
+func decodeDocuments(data []byte) ([]any, error) {
+	var docs []any
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		for {
+			var doc any
+			if err := decoder.Decode(&doc); err != nil {
+				if errors.Is(err, io.EOF) {
+					return docs, nil
+				}
+				return nil, err
+			}
+			docs = append(docs, doc)
+		}
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc any
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return docs, nil
+			}
+			return nil, err
+		}
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+}
+
+// toResource converts decoded document to Resource
+func toResource(doc any) (Resource, error) {
+	var r Resource
+
+	if _, ok := doc.(map[string]any); !ok {
+		return r, fmt.Errorf("resource should be an object")
+	}
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return r, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&r); err != nil {
+		return r, err
+	}
+	return r, nil
+}
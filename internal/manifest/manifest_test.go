@@ -0,0 +1,213 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/client"
+	"github.com/serverscom/srvctl/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var fixtureBasePath = filepath.Join("..", "..", "testdata", "manifests")
+
+func TestReadFiles(t *testing.T) {
+	g := NewWithT(t)
+
+	fromYAML, err := ReadFiles([]string{filepath.Join(fixtureBasePath, "ssh_keys.yaml")}, nil)
+	g.Expect(err).To(BeNil())
+	g.Expect(fromYAML).To(HaveLen(3))
+
+	fromJSON, err := ReadFiles([]string{filepath.Join(fixtureBasePath, "ssh_keys.json")}, nil)
+	g.Expect(err).To(BeNil())
+	g.Expect(fromJSON).To(HaveLen(3))
+
+	for i := range fromYAML {
+		g.Expect(fromYAML[i].Kind).To(Equal(fromJSON[i].Kind))
+		g.Expect(fromYAML[i].MatchLabel).To(Equal(fromJSON[i].MatchLabel))
+		g.Expect(fromYAML[i].Spec).To(Equal(fromJSON[i].Spec))
+	}
+	g.Expect(fromYAML[2].String()).To(Equal("ssh-keys/app=deploy"))
+	g.Expect(fromJSON[1].Source).To(HaveSuffix("ssh_keys.json[0][1]"))
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name        string
+		data        string
+		expectedLen int
+		expectError bool
+	}{
+		{
+			name:        "empty documents are skipped",
+			data:        "---\nkind: ssh-keys\nspec:\n  name: key\n---\n",
+			expectedLen: 1,
+		},
+		{
+			name:        "list of resources in yaml",
+			data:        "- kind: ssh-keys\n  spec:\n    name: a\n- kind: ssh-keys\n  spec:\n    name: b\n",
+			expectedLen: 2,
+		},
+		{
+			name:        "missing kind",
+			data:        "spec:\n  name: key\n",
+			expectError: true,
+		},
+		{
+			name:        "missing spec",
+			data:        "kind: ssh-keys\n",
+			expectError: true,
+		},
+		{
+			name:        "missing name",
+			data:        `{"kind": "ssh-keys", "spec": {"public_key": "key"}}`,
+			expectError: true,
+		},
+		{
+			name:        "missing match label value",
+			data:        `{"kind": "ssh-keys", "matchLabel": "app", "spec": {"labels": {"env": "prod"}}}`,
+			expectError: true,
+		},
+		{
+			name:        "unknown field",
+			data:        "kind: ssh-keys\nmetadata: {}\nspec:\n  name: key\n",
+			expectError: true,
+		},
+		{
+			name:        "invalid document",
+			data:        "just a string",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			resources, err := Parse([]byte(tc.data), "test")
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(BeNil())
+				g.Expect(resources).To(HaveLen(tc.expectedLen))
+			}
+		})
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	g := NewWithT(t)
+
+	current := map[string]any{
+		"name":   "old",
+		"size":   float64(10),
+		"labels": nil,
+	}
+	spec := map[string]any{
+		"name":        "new",
+		"size":        10,
+		"labels":      map[string]any{},
+		"description": "not in current state",
+	}
+
+	g.Expect(diffFields(current, spec)).To(Equal([]FieldChange{
+		{Field: "name", Current: "old", Desired: "new"},
+	}))
+}
+
+func TestApplyDryRun(t *testing.T) {
+	changes := []Change{
+		{
+			Kind:   "ssh-keys",
+			Name:   "new",
+			Action: ActionCreate,
+			spec:   map[string]any{"name": "new", "public_key": "ssh-ed25519 AAAA"},
+		},
+		{
+			Kind:   "ssh-keys",
+			Name:   "labeled",
+			ID:     "labeled-fingerprint",
+			Action: ActionUpdate,
+			spec:   map[string]any{"name": "labeled", "labels": map[string]any{"env": "prod"}},
+		},
+		{
+			Kind:   "ssh-keys",
+			Name:   "stale",
+			ID:     "stale-fingerprint",
+			Action: ActionDelete,
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		handlerErr       error
+		expectedErr      error
+		expectedApplied  int
+		expectedRequests []string
+	}{
+		{
+			name:             "all changes are printed",
+			expectedErr:      client.ErrDryRun,
+			expectedApplied:  3,
+			expectedRequests: []string{"POST /ssh_keys", "PUT /ssh_keys/labeled-fingerprint", "DELETE /ssh_keys/stale-fingerprint"},
+		},
+		{
+			name:             "handler error stops apply",
+			handlerErr:       errors.New("some error"),
+			expectedErr:      errors.New(`failed to create ssh-keys/new: POST "/ssh_keys": some error`),
+			expectedRequests: []string{"POST /ssh_keys"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			// in dry run mode requests fail with the error of the dry run transport wrapped by the HTTP client
+			var requests []string
+			send := func(method, path string) error {
+				requests = append(requests, method+" "+path)
+				err := client.ErrDryRun
+				if tc.handlerErr != nil {
+					err = tc.handlerErr
+				}
+				return &url.Error{Op: method, URL: path, Err: err}
+			}
+
+			sshKeysHandler := mocks.NewMockSSHKeysService(mockCtrl)
+			sshKeysHandler.EXPECT().Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(context.Context, serverscom.SSHKeyCreateInput) (*serverscom.SSHKey, error) {
+					return nil, send("POST", "/ssh_keys")
+				}).AnyTimes()
+			sshKeysHandler.EXPECT().Update(gomock.Any(), "labeled-fingerprint", gomock.Any()).
+				DoAndReturn(func(context.Context, string, serverscom.SSHKeyUpdateInput) (*serverscom.SSHKey, error) {
+					return nil, send("PUT", "/ssh_keys/labeled-fingerprint")
+				}).AnyTimes()
+			sshKeysHandler.EXPECT().Delete(gomock.Any(), "stale-fingerprint").
+				DoAndReturn(func(context.Context, string) error {
+					return send("DELETE", "/ssh_keys/stale-fingerprint")
+				}).AnyTimes()
+
+			scClient := serverscom.NewClientWithEndpoint("", "")
+			scClient.SSHKeys = sshKeysHandler
+
+			applied, err := Apply(context.Background(), scClient, changes)
+
+			if errors.Is(tc.expectedErr, client.ErrDryRun) {
+				g.Expect(errors.Is(err, client.ErrDryRun)).To(BeTrue())
+			} else {
+				g.Expect(err).To(MatchError(tc.expectedErr.Error()))
+			}
+			g.Expect(applied).To(HaveLen(tc.expectedApplied))
+			g.Expect(requests).To(Equal(tc.expectedRequests))
+		})
+	}
+}
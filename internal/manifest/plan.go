@@ -0,0 +1,324 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	srvclient "github.com/serverscom/srvctl/internal/client"
+)
+
+// Action represents an action required to bring a resource to the desired state
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionDelete    Action = "delete"
+	ActionUnchanged Action = "unchanged"
+)
+
+// sensitiveValue replaces values of sensitive fields in changes
+const sensitiveValue = "(sensitive value)"
+
+// sensitiveFields are spec fields which values are not shown in changes
+var sensitiveFields = []string{"private_key", "password"}
+
+// PlanOptions holds options of planning
+type PlanOptions struct {
+	// Prune plans deletion of existing resources which have a match label
+	// used in manifests of the same kind but are not described in them
+	Prune bool
+}
+
+// FieldChange represents a change of a single field
+type FieldChange struct {
+	Field   string `json:"field" yaml:"field"`
+	Current any    `json:"current" yaml:"current"`
+	Desired any    `json:"desired" yaml:"desired"`
+}
+
+// Change represents an action planned or applied to a resource
+type Change struct {
+	Kind   string        `json:"kind" yaml:"kind"`
+	Name   string        `json:"name" yaml:"name"`
+	ID     string        `json:"id,omitempty" yaml:"id,omitempty"`
+	Action Action        `json:"action" yaml:"action"`
+	Fields []FieldChange `json:"fields,omitempty" yaml:"fields,omitempty"`
+
+	spec map[string]any
+}
+
+// String returns human readable resource identifier
+func (c *Change) String() string {
+	return fmt.Sprintf("%s/%s", c.Kind, c.Name)
+}
+
+// HasChanges reports whether any of changes modifies a resource
+func HasChanges(changes []Change) bool {
+	for _, c := range changes {
+		if c.Action != ActionUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// Plan compares resources with the current state and returns changes
+// required to bring them to the desired state. It doesn't modify anything.
+func Plan(ctx context.Context, client *serverscom.Client, resources []Resource, opts PlanOptions) ([]Change, error) {
+	var kindNames []string
+	existing := make(map[string][]map[string]any)
+	matched := make(map[string][]string)
+	matchLabels := make(map[string][]string)
+
+	changes := make([]Change, 0, len(resources))
+	for _, r := range resources {
+		k, err := GetKind(r.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", r.Source, err)
+		}
+
+		items, ok := existing[r.Kind]
+		if !ok {
+			if items, err = k.List(ctx, client); err != nil {
+				return nil, fmt.Errorf("failed to list %s: %w", r.Kind, err)
+			}
+			existing[r.Kind] = items
+			kindNames = append(kindNames, r.Kind)
+		}
+
+		change, err := planResource(k, r, items)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+
+		if change.Action != ActionCreate {
+			matched[r.Kind] = append(matched[r.Kind], change.ID)
+		}
+		if r.MatchLabel != "" && !slices.Contains(matchLabels[r.Kind], r.MatchLabel) {
+			matchLabels[r.Kind] = append(matchLabels[r.Kind], r.MatchLabel)
+		}
+	}
+
+	if opts.Prune {
+		for _, name := range kindNames {
+			k, _ := GetKind(name)
+			changes = append(changes, planPrune(k, name, existing[name], matched[name], matchLabels[name])...)
+		}
+	}
+
+	return changes, nil
+}
+
+// planPrune returns deletions of existing resources which have one of match
+// labels but were not matched by any resource from manifests
+func planPrune(k Kind, kindName string, items []map[string]any, matched, matchLabels []string) []Change {
+	var result []Change
+
+	for _, item := range items {
+		id := fmt.Sprint(item[k.IDKey()])
+		if slices.Contains(matched, id) {
+			continue
+		}
+
+		labels, _ := item["labels"].(map[string]any)
+		for _, key := range matchLabels {
+			if v, ok := labels[key]; ok {
+				result = append(result, Change{
+					Kind:   kindName,
+					Name:   fmt.Sprintf("%s=%v", key, v),
+					ID:     id,
+					Action: ActionDelete,
+				})
+				break
+			}
+		}
+	}
+	return result
+}
+
+// planResource returns change for a single resource
+func planResource(k Kind, r Resource, items []map[string]any) (Change, error) {
+	change := Change{
+		Kind: r.Kind,
+		Name: r.Ref(),
+	}
+
+	matches := findMatches(r, items)
+	switch len(matches) {
+	case 0:
+		change.Action = ActionCreate
+		change.spec = r.Spec
+		change.Fields = specFields(r.Spec)
+		return change, nil
+	case 1:
+	default:
+		return change, fmt.Errorf("%s: %s matches %d existing resources", r.Source, r.String(), len(matches))
+	}
+
+	current := matches[0]
+	change.ID = fmt.Sprint(current[k.IDKey()])
+
+	spec, err := k.UpdatableSpec(r.Spec)
+	if err != nil {
+		return change, fmt.Errorf("%s: %w", r.Source, err)
+	}
+	change.spec = spec
+	change.Fields = diffFields(current, spec)
+
+	change.Action = ActionUnchanged
+	if len(change.Fields) > 0 {
+		change.Action = ActionUpdate
+	}
+	return change, nil
+}
+
+// findMatches returns existing resources matched by the name or the match label
+func findMatches(r Resource, items []map[string]any) []map[string]any {
+	var result []map[string]any
+
+	for _, item := range items {
+		if r.MatchLabel != "" {
+			want, _ := r.GetMatchLabelValue()
+			labels, _ := item["labels"].(map[string]any)
+			if v, ok := labels[r.MatchLabel]; ok && v == want {
+				result = append(result, item)
+			}
+			continue
+		}
+		if name, _ := item["name"].(string); name == r.GetName() {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// diffFields returns changed fields of spec in comparison to the current
+// state. Fields which are not present in the current state are skipped.
+func diffFields(current, spec map[string]any) []FieldChange {
+	var result []FieldChange
+
+	keys := make([]string, 0, len(spec))
+	for key := range spec {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		cur, ok := current[key]
+		if !ok {
+			continue
+		}
+		desired := normalize(spec[key])
+		if isEmpty(cur) && isEmpty(desired) {
+			continue
+		}
+		if !reflect.DeepEqual(cur, desired) {
+			result = append(result, FieldChange{Field: key, Current: cur, Desired: desired})
+		}
+	}
+	return result
+}
+
+// specFields returns all fields of spec as changes, values of sensitive
+// fields are hidden
+func specFields(spec map[string]any) []FieldChange {
+	keys := make([]string, 0, len(spec))
+	for key := range spec {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	result := make([]FieldChange, 0, len(keys))
+	for _, key := range keys {
+		var desired any = sensitiveValue
+		if !slices.Contains(sensitiveFields, key) {
+			desired = normalize(spec[key])
+		}
+		result = append(result, FieldChange{Field: key, Desired: desired})
+	}
+	return result
+}
+
+// normalize converts value to the same types as decoded from JSON
+func normalize(v any) any {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var result any
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return v
+	}
+	return result
+}
+
+// isEmpty reports whether v is nil or an empty map or slice
+func isEmpty(v any) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(val) == 0
+	case []any:
+		return len(val) == 0
+	}
+	return false
+}
+
+// Apply performs planned changes. It stops on the first error and returns
+// changes applied so far. In dry run mode requests of all changes are printed
+// by the client and client.ErrDryRun is returned after the last one.
+func Apply(ctx context.Context, client *serverscom.Client, changes []Change) ([]Change, error) {
+	applied := make([]Change, 0, len(changes))
+
+	var dryRun bool
+	for _, change := range changes {
+		err := applyChange(ctx, client, &change)
+		switch {
+		case errors.Is(err, srvclient.ErrDryRun):
+			// the request is printed instead of being sent, the next ones are printed too
+			dryRun = true
+		case err != nil:
+			return applied, err
+		}
+		applied = append(applied, change)
+	}
+
+	if dryRun {
+		return applied, srvclient.ErrDryRun
+	}
+	return applied, nil
+}
+
+// applyChange performs a single change, the id of a created resource is set to it
+func applyChange(ctx context.Context, client *serverscom.Client, change *Change) error {
+	k, err := GetKind(change.Kind)
+	if err != nil {
+		return err
+	}
+
+	switch change.Action {
+	case ActionCreate:
+		v, err := k.Create(ctx, client, change.spec)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", change.String(), err)
+		}
+		change.ID = fmt.Sprint(v[k.IDKey()])
+	case ActionUpdate:
+		if _, err := k.Update(ctx, client, change.ID, change.spec); err != nil {
+			return fmt.Errorf("failed to update %s: %w", change.String(), err)
+		}
+	case ActionDelete:
+		if err := k.Delete(ctx, client, change.ID); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", change.String(), err)
+		}
+	}
+	return nil
+}
@@ -13,20 +13,20 @@ const (
 
 // HostMetric represents metrics of a single host.
 type HostMetric struct {
-	HostID          string
-	Title           string
-	HostType        string
-	ChassisName     string
-	LocationID      string
-	LocationCode    string
-	RackID          string
-	RackType        string
-	PublicSent      int64
-	PublicReceived  int64
-	PrivateSent     int64
-	PrivateReceived int64
-	TotalSent       int64
-	TotalReceived   int64
+	HostID          string `json:"host_id"`
+	Title           string `json:"title"`
+	HostType        string `json:"host_type"`
+	ChassisName     string `json:"chassis_name"`
+	LocationID      string `json:"location_id"`
+	LocationCode    string `json:"location_code"`
+	RackID          string `json:"rack_id"`
+	RackType        string `json:"rack_type"`
+	PublicSent      int64  `json:"public_sent"`
+	PublicReceived  int64  `json:"public_received"`
+	PrivateSent     int64  `json:"private_sent"`
+	PrivateReceived int64  `json:"private_received"`
+	TotalSent       int64  `json:"total_sent"`
+	TotalReceived   int64  `json:"total_received"`
 }
 
 // BuildHostRows folds hosts metrics samples into one row per host.
@@ -20,23 +20,23 @@ const (
 // Power and current are summed per device type. PDU and ATS are kept apart
 // because an ATS feeds the PDUs, so summing them would count the same draw twice.
 type RackMetric struct {
-	RackID          string
-	Title           string
-	LocationID      string
-	LocationCode    string
-	Hosts           int64
-	PublicSent      int64
-	PublicReceived  int64
-	PrivateSent     int64
-	PrivateReceived int64
-	TotalSent       int64
-	TotalReceived   int64
-	PduWatts        float64
-	PduAmperes      float64
-	PduCount        int
-	AtsWatts        float64
-	AtsAmperes      float64
-	AtsCount        int
+	RackID          string  `json:"rack_id"`
+	Title           string  `json:"title"`
+	LocationID      string  `json:"location_id"`
+	LocationCode    string  `json:"location_code"`
+	Hosts           int64   `json:"hosts"`
+	PublicSent      int64   `json:"public_sent"`
+	PublicReceived  int64   `json:"public_received"`
+	PrivateSent     int64   `json:"private_sent"`
+	PrivateReceived int64   `json:"private_received"`
+	TotalSent       int64   `json:"total_sent"`
+	TotalReceived   int64   `json:"total_received"`
+	PduWatts        float64 `json:"pdu_watts"`
+	PduAmperes      float64 `json:"pdu_amperes"`
+	PduCount        int     `json:"pdu_count"`
+	AtsWatts        float64 `json:"ats_watts"`
+	AtsAmperes      float64 `json:"ats_amperes"`
+	AtsCount        int     `json:"ats_count"`
 }
 
 // BuildRackRows folds racks metrics samples into one row per rack.
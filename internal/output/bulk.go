@@ -0,0 +1,34 @@
+package output
+
+import (
+	"fmt"
+	"text/tabwriter"
+)
+
+const (
+	BulkStatusSuccess = "success"
+	BulkStatusError   = "error"
+)
+
+// BulkResult represents a result of an action for a single resource
+type BulkResult struct {
+	ID     string `json:"id" yaml:"id"`
+	Status string `json:"status" yaml:"status"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// FormatBulkResults formats results of an action for multiple resources
+func (f *Formatter) FormatBulkResults(results []BulkResult) error {
+	if f.IsStructured() {
+		return f.Format(results)
+	}
+
+	w := tabwriter.NewWriter(f.writer, 0, 0, 3, ' ', 0)
+	if f.header {
+		fmt.Fprintln(w, "ID\tSTATUS\tERROR")
+	}
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.ID, r.Status, r.Error)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/serverscom/srvctl/internal/cache"
+)
+
+// FormatCacheInfo formats cache statistics
+func (f *Formatter) FormatCacheInfo(info *cache.Info) error {
+	if f.IsStructured() {
+		return f.Format(info)
+	}
+
+	w := tabwriter.NewWriter(f.writer, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "Directory:\t%s\n", info.Dir)
+	fmt.Fprintf(w, "TTL:\t%s\n", info.TTL)
+	fmt.Fprintf(w, "Entries:\t%d\n", info.Entries)
+	fmt.Fprintf(w, "Expired:\t%d\n", info.Expired)
+	fmt.Fprintf(w, "Size:\t%d bytes\n", info.Size)
+	if info.Oldest != nil {
+		fmt.Fprintf(w, "Oldest:\t%s\n", info.Oldest.Format(time.RFC3339))
+	}
+	if info.Newest != nil {
+		fmt.Fprintf(w, "Newest:\t%s\n", info.Newest.Format(time.RFC3339))
+	}
+	return w.Flush()
+}
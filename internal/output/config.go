@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sort"
 	"text/tabwriter"
+
+	"github.com/serverscom/srvctl/internal/config"
 )
 
 type ConfigInfo struct {
@@ -29,3 +31,38 @@ func (f *Formatter) formatConfig(cfgInfo ConfigInfo) error {
 
 	return w.Flush()
 }
+
+// ConfigExplanation shows config layers setting a config key, the value is taken from the first one
+type ConfigExplanation struct {
+	Key    string                 `json:"key" yaml:"key"`
+	Value  any                    `json:"value" yaml:"value"`
+	Layer  string                 `json:"layer" yaml:"layer"`
+	Source string                 `json:"source,omitempty" yaml:"source,omitempty"`
+	Layers []config.ResolvedValue `json:"layers" yaml:"layers"`
+}
+
+func (f *Formatter) formatConfigExplanation(explanation ConfigExplanation) error {
+	w := tabwriter.NewWriter(f.writer, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "Key:\t%s\n", explanation.Key)
+	fmt.Fprintf(w, "Value:\t%v\n", explanation.Value)
+	if explanation.Source != "" {
+		fmt.Fprintf(w, "Layer:\t%s (%s)\n", explanation.Layer, explanation.Source)
+	} else {
+		fmt.Fprintf(w, "Layer:\t%s\n", explanation.Layer)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(f.writer, "\nLayers:")
+	w = tabwriter.NewWriter(f.writer, 0, 0, 3, ' ', 0)
+	for i, layer := range explanation.Layers {
+		mark := " "
+		if i == 0 {
+			mark = "*"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\t%v\n", mark, layer.Layer, layer.Source, layer.Value)
+	}
+
+	return w.Flush()
+}
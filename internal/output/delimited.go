@@ -0,0 +1,47 @@
+package output
+
+import (
+	"encoding/csv"
+	"reflect"
+
+	"github.com/serverscom/srvctl/internal/output/entities"
+)
+
+// formatDelimited formats the given data as a table with values separated by
+// comma, values are quoted as described in RFC 4180. Columns are the same as in
+// the text output.
+func (f *Formatter) formatDelimited(v any, comma rune) error {
+	entity, err := entities.Registry.GetEntityFromValue(v)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(f.writer)
+	w.Comma = comma
+
+	orderedFields := f.getOrderedFields(entity)
+
+	if f.header {
+		headers := make([]string, 0, len(orderedFields))
+		for _, field := range orderedFields {
+			headers = append(headers, field.GetName())
+		}
+		if err := w.Write(headers); err != nil {
+			return err
+		}
+	}
+
+	err = processValue(reflect.ValueOf(v), func(item any) error {
+		values, err := rowValues(item, orderedFields)
+		if err != nil {
+			return err
+		}
+		return w.Write(values)
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Flush()
+	return w.Error()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 )
 
 // RegistryInterface represents the interface for the EntityRegistry
@@ -21,6 +22,7 @@ type EntityInterface interface {
 	GetDefaultFields() []string
 	GetCmdDefaultFields(cmd string) []string
 	GetFields() []Field
+	GetField(id string) (*Field, error)
 	Validate(fields []string) error
 }
 
@@ -113,6 +115,19 @@ func (e *Entity) GetFields() []Field {
 	return e.fields
 }
 
+// GetField returns a field or a child field by its ID. IDs are matched
+// case-insensitively ignoring underscores, so location_code matches LocationCode.
+// Top level fields are also matched by their JSON names, e.g. scheduled_release_at.
+func (e *Entity) GetField(id string) (*Field, error) {
+	if f := findField(e.fields, id); f != nil {
+		return f, nil
+	}
+	if f := e.findFieldByJSONName(id); f != nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("field %s is not found, try --field-list to get available fields", id)
+}
+
 // Validate checks that all fields match available ones. A leading +/-
 // prefix (used to add/remove a field relative to the default set) is
 // stripped before matching.
@@ -170,3 +185,34 @@ func (e *Entity) fieldInChildFields(childFields []Field, fieldID string) bool {
 	}
 	return false
 }
+
+// findField searches a field by its ID in fields and their child fields
+func findField(fields []Field, id string) *Field {
+	for i := range fields {
+		if strings.EqualFold(fields[i].ID, id) || strings.EqualFold(fields[i].ID, strings.ReplaceAll(id, "_", "")) {
+			return &fields[i]
+		}
+		if f := findField(fields[i].ChildFields, id); f != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// findFieldByJSONName searches a top level field by the JSON name of its struct field
+func (e *Entity) findFieldByJSONName(name string) *Field {
+	if e.eType == nil || e.eType.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := range e.fields {
+		sf, ok := e.eType.FieldByName(e.fields[i].Path)
+		if !ok {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if jsonName != "" && jsonName == name {
+			return &e.fields[i]
+		}
+	}
+	return nil
+}
@@ -34,3 +34,29 @@ func TestEntityValidateWithFieldPrefix(t *testing.T) {
 	g.Expect(entity.Validate([]string{"-Name"})).To(BeNil())
 	g.Expect(entity.Validate([]string{"+Unknown"})).To(HaveOccurred())
 }
+
+func TestEntityGetField(t *testing.T) {
+	g := NewWithT(t)
+
+	entity, err := Registry.GetEntityFromValue(serverscom.DedicatedServer{})
+	g.Expect(err).To(BeNil())
+
+	f, err := entity.GetField("status")
+	g.Expect(err).To(BeNil())
+	g.Expect(f.GetPath()).To(Equal("Status"))
+
+	f, err = entity.GetField("RAMSize")
+	g.Expect(err).To(BeNil())
+	g.Expect(f.GetPath()).To(Equal("ConfigurationDetails.RAMSize"))
+
+	f, err = entity.GetField("location_code")
+	g.Expect(err).To(BeNil())
+	g.Expect(f.GetPath()).To(Equal("LocationCode"))
+
+	f, err = entity.GetField("scheduled_release_at")
+	g.Expect(err).To(BeNil())
+	g.Expect(f.GetPath()).To(Equal("ScheduledRelease"))
+
+	_, err = entity.GetField("Unknown")
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"log"
+	"reflect"
+
+	"github.com/serverscom/srvctl/internal/search"
+)
+
+var (
+	SearchResultType = reflect.TypeFor[search.Result]()
+)
+
+// RegisterSearchResultDefinition registers search results entity
+func RegisterSearchResultDefinition() {
+	searchResultEntity := &Entity{
+		fields: []Field{
+			{ID: "Kind", Name: "Kind", Path: "Kind", ListHandlerFunc: stringHandler, PageViewHandlerFunc: stringHandler, Default: true},
+			{ID: "ID", Name: "ID", Path: "ID", ListHandlerFunc: stringHandler, PageViewHandlerFunc: stringHandler, Default: true},
+			{ID: "Name", Name: "Name", Path: "Name", ListHandlerFunc: stringHandler, PageViewHandlerFunc: stringHandler, Default: true},
+			{ID: "Location", Name: "Location", Path: "Location", ListHandlerFunc: stringHandler, PageViewHandlerFunc: stringHandler, Default: true},
+			{ID: "Field", Name: "Matched", Path: "Field", ListHandlerFunc: stringHandler, PageViewHandlerFunc: stringHandler, Default: true},
+			{ID: "Value", Name: "Value", Path: "Value", ListHandlerFunc: stringHandler, PageViewHandlerFunc: stringHandler, Default: true},
+		},
+		eType: SearchResultType,
+	}
+
+	if err := Registry.Register(searchResultEntity); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,306 @@
+// Package filter implements client-side filtering and sorting of list
+// command items by entity fields.
+package filter
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/serverscom/srvctl/internal/output/entities"
+	"github.com/serverscom/srvctl/internal/output/utils"
+)
+
+const (
+	// operatorChars start an operator in a condition
+	operatorChars = "=!~<>"
+	// dateFormat is accepted along with RFC 3339 in date comparisons
+	dateFormat = time.DateOnly
+)
+
+// operators are supported condition operators, longer ones go first
+var operators = []string{"!=", "<=", ">=", "=", "~", "<", ">"}
+
+// Condition is a parsed 'field<op>value' filter
+type Condition struct {
+	field  *entities.Field
+	op     string
+	value  string
+	re     *regexp.Regexp
+	number *float64
+	date   *time.Time
+}
+
+// Parse parses conditions like 'status=active' or 'location_code~AMS'
+// against fields of the entity
+func Parse(entity entities.EntityInterface, exprs []string) ([]*Condition, error) {
+	conditions := make([]*Condition, 0, len(exprs))
+	for _, expr := range exprs {
+		c, err := parseCondition(entity, expr)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, c)
+	}
+	return conditions, nil
+}
+
+// parseCondition parses a single condition
+func parseCondition(entity entities.EntityInterface, expr string) (*Condition, error) {
+	i := strings.IndexAny(expr, operatorChars)
+	name := strings.TrimSpace(expr[:max(i, 0)])
+	if i < 0 || name == "" {
+		return nil, fmt.Errorf("invalid filter %q, expected 'field<op>value' where op is one of %s", expr, strings.Join(operators, ", "))
+	}
+
+	var op string
+	for _, o := range operators {
+		if strings.HasPrefix(expr[i:], o) {
+			op = o
+			break
+		}
+	}
+	if op == "" {
+		return nil, fmt.Errorf("invalid filter %q: unknown operator, use one of %s", expr, strings.Join(operators, ", "))
+	}
+
+	field, err := entity.GetField(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Condition{field: field, op: op, value: strings.TrimSpace(expr[i+len(op):])}
+
+	switch op {
+	case "~":
+		if c.re, err = regexp.Compile(c.value); err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %w", expr, err)
+		}
+	case "<", "<=", ">", ">=":
+		if n, err := strconv.ParseFloat(c.value, 64); err == nil {
+			c.number = &n
+		} else if d, ok := parseDate(c.value); ok {
+			c.date = &d
+		} else {
+			return nil, fmt.Errorf("invalid filter %q: %s compares numbers or dates like %s", expr, op, dateFormat)
+		}
+	}
+
+	return c, nil
+}
+
+// Match reports whether the item satisfies the condition
+func (c *Condition) Match(item any) (bool, error) {
+	v, err := fieldValue(item, c.field)
+	if err != nil {
+		return false, err
+	}
+
+	switch c.op {
+	case "=":
+		return toString(v) == c.value, nil
+	case "!=":
+		return toString(v) != c.value, nil
+	case "~":
+		return c.re.MatchString(toString(v)), nil
+	}
+
+	// unset values are neither less nor greater than anything
+	if v == nil {
+		return false, nil
+	}
+
+	var result int
+	switch {
+	case c.date != nil:
+		d, ok := toDate(v)
+		if !ok {
+			return false, fmt.Errorf("field %s is not a date, it can't be compared with %s", c.field.ID, c.value)
+		}
+		result = d.Compare(*c.date)
+	default:
+		n, ok := toNumber(v)
+		if !ok {
+			return false, fmt.Errorf("field %s is not a number, it can't be compared with %s", c.field.ID, c.value)
+		}
+		result = cmp.Compare(n, *c.number)
+	}
+
+	switch c.op {
+	case "<":
+		return result < 0, nil
+	case "<=":
+		return result <= 0, nil
+	case ">":
+		return result > 0, nil
+	default:
+		return result >= 0, nil
+	}
+}
+
+// Apply returns items satisfying all conditions
+func Apply[T any](items []T, conditions []*Condition) ([]T, error) {
+	if len(conditions) == 0 {
+		return items, nil
+	}
+
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		matched := true
+		for _, c := range conditions {
+			ok, err := c.Match(item)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// Sort sorts items in place by the field value. Numbers and dates are
+// compared by value, other values as strings, unset values go last.
+func Sort[T any](items []T, field *entities.Field, desc bool) error {
+	keys := make([]any, len(items))
+	indexes := make([]int, len(items))
+	for i, item := range items {
+		v, err := fieldValue(item, field)
+		if err != nil {
+			return err
+		}
+		keys[i] = v
+		indexes[i] = i
+	}
+
+	slices.SortStableFunc(indexes, func(a, b int) int {
+		ka, kb := keys[a], keys[b]
+		if desc && ka != nil && kb != nil {
+			ka, kb = kb, ka
+		}
+		return compareValues(ka, kb)
+	})
+
+	sorted := make([]T, len(items))
+	for i, idx := range indexes {
+		sorted[i] = items[idx]
+	}
+	copy(items, sorted)
+	return nil
+}
+
+// compareValues compares two field values
+func compareValues(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+
+	if da, ok := a.(time.Time); ok {
+		if db, ok := b.(time.Time); ok {
+			return da.Compare(db)
+		}
+	}
+	if isNumber(a) && isNumber(b) {
+		na, _ := toNumber(a)
+		nb, _ := toNumber(b)
+		return cmp.Compare(na, nb)
+	}
+	return strings.Compare(toString(a), toString(b))
+}
+
+// fieldValue returns the field value of the item, pointers are dereferenced
+// and nil pointers are returned as nil
+func fieldValue(item any, field *entities.Field) (any, error) {
+	v, err := utils.GetFieldValue(item, field.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	return rv.Interface(), nil
+}
+
+// toString formats a value the way list output shows it, unset values are empty
+func toString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// isNumber reports whether a value has a numeric type
+func isNumber(v any) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// toNumber converts numbers and numeric strings to float
+func toNumber(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.String:
+		n, err := strconv.ParseFloat(rv.String(), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// toDate converts dates and date strings to time
+func toDate(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		return parseDate(v)
+	}
+	return time.Time{}, false
+}
+
+// parseDate parses RFC 3339 timestamps and dates
+func parseDate(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, dateFormat} {
+		if d, err := time.Parse(layout, s); err == nil {
+			return d, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,170 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+	"github.com/serverscom/srvctl/internal/output/entities"
+)
+
+var (
+	releaseAt   = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	testServers = []serverscom.DedicatedServer{
+		{ID: "a1", Title: "web-01", Status: "active", LocationID: 1, LocationCode: "AMS1", ScheduledRelease: &releaseAt, Created: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "b2", Title: "db-01", Status: "init", LocationID: 2, LocationCode: "WDC1", Created: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "c3", Title: "web-02", Status: "active", LocationID: 10, LocationCode: "AMS2", Created: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+)
+
+// ids returns ids of servers
+func ids(servers []serverscom.DedicatedServer) []string {
+	result := make([]string, 0, len(servers))
+	for _, s := range servers {
+		result = append(result, s.ID)
+	}
+	return result
+}
+
+func TestApply(t *testing.T) {
+	testCases := []struct {
+		name        string
+		filters     []string
+		expectedIDs []string
+		expectError bool
+	}{
+		{
+			name:        "equal",
+			filters:     []string{"status=active"},
+			expectedIDs: []string{"a1", "c3"},
+		},
+		{
+			name:        "not equal",
+			filters:     []string{"Status!=active"},
+			expectedIDs: []string{"b2"},
+		},
+		{
+			name:        "regexp and equal",
+			filters:     []string{"status=active", "location_code~^AMS"},
+			expectedIDs: []string{"a1", "c3"},
+		},
+		{
+			name:        "set value",
+			filters:     []string{"scheduled_release_at!="},
+			expectedIDs: []string{"a1"},
+		},
+		{
+			name:        "numbers",
+			filters:     []string{"LocationID>1"},
+			expectedIDs: []string{"b2", "c3"},
+		},
+		{
+			name:        "dates",
+			filters:     []string{"Created>=2024-01-01"},
+			expectedIDs: []string{"a1", "b2"},
+		},
+		{
+			name:        "unset date is not compared",
+			filters:     []string{"ScheduledRelease<2030-01-01T00:00:00Z"},
+			expectedIDs: []string{"a1"},
+		},
+		{
+			name:        "no operator",
+			filters:     []string{"status"},
+			expectError: true,
+		},
+		{
+			name:        "unknown field",
+			filters:     []string{"unknown=1"},
+			expectError: true,
+		},
+		{
+			name:        "invalid regexp",
+			filters:     []string{"title~("},
+			expectError: true,
+		},
+		{
+			name:        "comparison with a string",
+			filters:     []string{"LocationID>one"},
+			expectError: true,
+		},
+		{
+			name:        "number comparison of a string field",
+			filters:     []string{"Title>1"},
+			expectError: true,
+		},
+	}
+
+	entity, err := entities.Registry.GetEntityFromValue(testServers)
+	NewWithT(t).Expect(err).To(BeNil())
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			conditions, err := Parse(entity, tc.filters)
+			if err == nil {
+				var result []serverscom.DedicatedServer
+				result, err = Apply(testServers, conditions)
+				if err == nil {
+					g.Expect(ids(result)).To(Equal(tc.expectedIDs))
+				}
+			}
+
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	testCases := []struct {
+		name        string
+		field       string
+		desc        bool
+		expectedIDs []string
+	}{
+		{
+			name:        "strings",
+			field:       "Title",
+			expectedIDs: []string{"b2", "a1", "c3"},
+		},
+		{
+			name:        "numbers",
+			field:       "location_id",
+			desc:        true,
+			expectedIDs: []string{"c3", "b2", "a1"},
+		},
+		{
+			name:        "dates",
+			field:       "Created",
+			expectedIDs: []string{"c3", "a1", "b2"},
+		},
+		{
+			name:        "unset values go last",
+			field:       "ScheduledRelease",
+			desc:        true,
+			expectedIDs: []string{"a1", "b2", "c3"},
+		},
+	}
+
+	entity, err := entities.Registry.GetEntityFromValue(testServers)
+	NewWithT(t).Expect(err).To(BeNil())
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			field, err := entity.GetField(tc.field)
+			g.Expect(err).To(BeNil())
+
+			servers := append([]serverscom.DedicatedServer{}, testServers...)
+			g.Expect(Sort(servers, field, tc.desc)).To(Succeed())
+			g.Expect(ids(servers)).To(Equal(tc.expectedIDs))
+		})
+	}
+}
@@ -2,13 +2,30 @@ package output
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/serverscom/srvctl/internal/config"
+	"github.com/serverscom/srvctl/internal/output/query"
 	"github.com/serverscom/srvctl/internal/output/skeletons"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
-	"html/template"
 	"io"
 	"io/fs"
+	"reflect"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+var (
+	// structuredOutputs print data as is instead of tables
+	structuredOutputs = []string{"json", "ndjson", "yaml", "jsonpath", "jq"}
+	// expressionOutputs require an expression to evaluate against data
+	expressionOutputs = []string{"jsonpath", "jq"}
+	// expressionExamples are shown if an expression is missing
+	expressionExamples = map[string]string{
+		"jsonpath": "'{.items[*].id}'",
+		"jq":       "'.[].id'",
+	}
 )
 
 // Formatter represents formatter struct with custom io.Writer
@@ -21,7 +38,11 @@ type Formatter struct {
 	fieldsToShow []string
 	fieldList    bool
 	header       bool
+	fixedWidths  bool
 	cmdName      string
+	expression   string
+	query        query.Query
+	capture      func(any)
 }
 
 // NewFormatter creates new formatter with specified io.Writer
@@ -32,15 +53,21 @@ func NewFormatter(cmd *cobra.Command, manager *config.Manager) *Formatter {
 	fields, _ := manager.GetResolvedStringSliceValue(cmd, "field")
 	fieldList, _ := manager.GetResolvedBoolValue(cmd, "field-list")
 	noHeader, _ := manager.GetResolvedBoolValue(cmd, "no-header")
+	fixedWidths, _ := manager.GetResolvedBoolValue(cmd, "fixed-widths")
+
+	// expression outputs are passed as -o jsonpath=<expr> or -o jq=<expr>
+	output, expression, _ := strings.Cut(output, "=")
 
 	return &Formatter{
 		writer:       cmd.OutOrStdout(),
 		output:       output,
+		expression:   expression,
 		templateStr:  template,
 		pageView:     pageView,
 		fieldsToShow: fields,
 		fieldList:    fieldList,
 		header:       !noHeader,
+		fixedWidths:  fixedWidths,
 		cmdName:      cmd.Name(),
 	}
 }
@@ -55,6 +82,36 @@ func (f *Formatter) GetTemplateStr() string {
 	return f.templateStr
 }
 
+// IsStructured checks if output prints data as is instead of tables,
+// such outputs are used for values which have no entity definitions too
+func (f *Formatter) IsStructured() bool {
+	return slices.Contains(structuredOutputs, f.output)
+}
+
+// CompileExpression parses an expression of jsonpath and jq outputs
+func (f *Formatter) CompileExpression() error {
+	if f.query != nil || !slices.Contains(expressionOutputs, f.output) {
+		return nil
+	}
+	if f.expression == "" {
+		return fmt.Errorf("%s output requires an expression, e.g. -o %s=%s", f.output, f.output, expressionExamples[f.output])
+	}
+
+	var err error
+	switch f.output {
+	case "jsonpath":
+		f.query, err = query.ParseJSONPath(f.expression)
+	case "jq":
+		f.query, err = query.ParseJQ(f.expression)
+	}
+	return err
+}
+
+// GetPageView returns true if page view format is used
+func (f *Formatter) GetPageView() bool {
+	return f.pageView
+}
+
 // SetTemplate sets template
 func (f *Formatter) SetTemplate(t *template.Template) {
 	f.template = t
@@ -65,8 +122,19 @@ func (f *Formatter) SetOutput(o string) {
 	f.output = o
 }
 
+// SetCapture sets a function which receives values passed to Format instead
+// of printing them, nil restores printing
+func (f *Formatter) SetCapture(fn func(any)) {
+	f.capture = fn
+}
+
 // Format formats data according to format
 func (f *Formatter) Format(v any) error {
+	if f.capture != nil {
+		f.capture(v)
+		return nil
+	}
+
 	switch f.output {
 	case "json":
 		data, err := json.MarshalIndent(v, "", "    ")
@@ -76,8 +144,19 @@ func (f *Formatter) Format(v any) error {
 		data = append(data, '\n')
 		_, err = f.writer.Write(data)
 		return err
+	case "ndjson":
+		return f.formatNDJSON(v)
 	case "yaml":
 		return yaml.NewEncoder(f.writer).Encode(v)
+	case "jsonpath", "jq":
+		if err := f.CompileExpression(); err != nil {
+			return err
+		}
+		return f.query.Execute(f.writer, v)
+	case "csv":
+		return f.formatDelimited(v, ',')
+	case "tsv":
+		return f.formatDelimited(v, '\t')
 	default:
 		return f.FormatText(v)
 	}
@@ -88,6 +167,8 @@ func (f *Formatter) FormatText(v any) error {
 	switch data := v.(type) {
 	case ConfigInfo:
 		return f.formatConfig(data)
+	case ConfigExplanation:
+		return f.formatConfigExplanation(data)
 	default:
 		return f.formatText(data)
 	}
@@ -104,3 +185,16 @@ func (f *Formatter) FormatSkeleton(path string) error {
 
 	return f.Format(json.RawMessage(raw))
 }
+
+// formatNDJSON formats the given data as newline-delimited JSON: one compact
+// object per line for each item of a list, a single line for other values
+func (f *Formatter) formatNDJSON(v any) error {
+	enc := json.NewEncoder(f.writer)
+	enc.SetEscapeHTML(false)
+
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Slice || value.Type().Elem().Kind() == reflect.Uint8 {
+		return enc.Encode(v)
+	}
+	return processValue(value, enc.Encode)
+}
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/serverscom/srvctl/internal/inventory"
+)
+
+// inventoryMarks maps inventory diff actions to marks
+var inventoryMarks = map[inventory.Action]string{
+	inventory.ActionAdded:   "+",
+	inventory.ActionRemoved: "-",
+	inventory.ActionChanged: "~",
+}
+
+// FormatInventoryDiff formats differences between two inventory snapshots
+func (f *Formatter) FormatInventoryDiff(changes []inventory.Change) error {
+	if f.IsStructured() {
+		if changes == nil {
+			changes = []inventory.Change{}
+		}
+		return f.Format(changes)
+	}
+
+	counts := make(map[inventory.Action]int)
+	for _, c := range changes {
+		counts[c.Action]++
+
+		header := fmt.Sprintf("%s %s", inventoryMarks[c.Action], c.String())
+		if c.Name != "" {
+			header += fmt.Sprintf(" (%s)", c.Name)
+		}
+		if _, err := fmt.Fprintln(f.writer, header); err != nil {
+			return err
+		}
+
+		for _, field := range c.Fields {
+			line := fmt.Sprintf("    ~ %s: %s => %s", field.Field, diffValue(field.Old), diffValue(field.New))
+			if _, err := fmt.Fprintln(f.writer, line); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(changes) > 0 {
+		if _, err := fmt.Fprintln(f.writer); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(f.writer, "Inventory: %d added, %d removed, %d changed.\n",
+		counts[inventory.ActionAdded], counts[inventory.ActionRemoved], counts[inventory.ActionChanged])
+	return err
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/serverscom/srvctl/internal/manifest"
+)
+
+// appliedActions maps actions to the past tense used in apply results
+var appliedActions = map[manifest.Action]string{
+	manifest.ActionCreate:    "created",
+	manifest.ActionUpdate:    "updated",
+	manifest.ActionDelete:    "deleted",
+	manifest.ActionUnchanged: "unchanged",
+}
+
+// FormatApplyResults formats changes applied from manifests
+func (f *Formatter) FormatApplyResults(changes []manifest.Change) error {
+	if f.IsStructured() {
+		return f.Format(changes)
+	}
+
+	for _, c := range changes {
+		if _, err := fmt.Fprintf(f.writer, "%s %s (%s)\n", c.String(), appliedActions[c.Action], c.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffMarks maps actions to marks used in diff
+var diffMarks = map[manifest.Action]string{
+	manifest.ActionCreate: "+",
+	manifest.ActionUpdate: "~",
+	manifest.ActionDelete: "-",
+}
+
+// FormatDiff formats planned changes as a field-level diff
+func (f *Formatter) FormatDiff(changes []manifest.Change) error {
+	if f.IsStructured() {
+		return f.Format(changes)
+	}
+
+	var hasChanges bool
+	counts := make(map[manifest.Action]int)
+	for _, c := range changes {
+		counts[c.Action]++
+
+		mark, ok := diffMarks[c.Action]
+		if !ok {
+			continue
+		}
+		hasChanges = true
+		header := fmt.Sprintf("%s %s", mark, c.String())
+		if c.ID != "" {
+			header += fmt.Sprintf(" (%s)", c.ID)
+		}
+		if _, err := fmt.Fprintln(f.writer, header); err != nil {
+			return err
+		}
+
+		for _, field := range c.Fields {
+			var line string
+			if c.Action == manifest.ActionCreate {
+				line = fmt.Sprintf("    + %s: %s", field.Field, diffValue(field.Desired))
+			} else {
+				line = fmt.Sprintf("    ~ %s: %s => %s", field.Field, diffValue(field.Current), diffValue(field.Desired))
+			}
+			if _, err := fmt.Fprintln(f.writer, line); err != nil {
+				return err
+			}
+		}
+	}
+
+	if hasChanges {
+		if _, err := fmt.Fprintln(f.writer); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(f.writer, "Plan: %d to create, %d to update, %d to delete, %d unchanged.\n",
+		counts[manifest.ActionCreate], counts[manifest.ActionUpdate], counts[manifest.ActionDelete], counts[manifest.ActionUnchanged])
+	return err
+}
+
+// diffValue returns value formatted as JSON
+func diffValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,88 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/itchyny/gojq"
+)
+
+// JQ is a compiled jq expression
+type JQ struct {
+	expr string
+	code *gojq.Code
+}
+
+// ParseJQ parses a jq expression
+func ParseJQ(expr string) (*JQ, error) {
+	parsed, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression %q: %w", expr, err)
+	}
+	code, err := gojq.Compile(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression %q: %w", expr, err)
+	}
+	return &JQ{expr: expr, code: code}, nil
+}
+
+// Execute evaluates the expression against v and writes each output on a separate
+// line, strings are written as is, like with 'jq -r'
+func (q *JQ) Execute(w io.Writer, v any) error {
+	data, err := normalize(v)
+	if err != nil {
+		return err
+	}
+
+	var outputs int
+	iter := q.code.Run(data)
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := result.(error); ok {
+			var haltErr *gojq.HaltError
+			if errors.As(err, &haltErr) && haltErr.Value() == nil {
+				break
+			}
+			return err
+		}
+
+		s, err := formatRaw(result)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w, s); err != nil {
+			return err
+		}
+		outputs++
+	}
+
+	if outputs == 0 {
+		return errNoMatch(q.expr)
+	}
+	return nil
+}
+
+// formatRaw formats a jq output: strings are printed as is, other values are
+// printed as indented JSON
+func formatRaw(v any) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// titles often have & or <, they must stay as is
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,455 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/itchyny/gojq"
+)
+
+// JSONPath is a template with JSONPath expressions in braces as used by kubectl,
+// e.g. '{range .items[*]}{.id}{"\t"}{.title}{"\n"}{end}'. Lists are queried
+// as 'items' of an object like kubectl lists. Missing keys are errors.
+//
+// Expressions are translated to jq and evaluated by gojq. Fields, '..' recursive
+// descent, '[n]' indexes, '[a:b]' slices, '[*]' wildcards, '[?(@.key <op> value)]'
+// filters, quoted strings and 'range'/'end' blocks are supported.
+type JSONPath struct {
+	expr  string
+	nodes []templateNode
+}
+
+type nodeKind int
+
+const (
+	nodeText nodeKind = iota
+	nodeExpr
+	nodeRange
+)
+
+// templateNode is a text, an expression or a range block of a template
+type templateNode struct {
+	kind nodeKind
+	text string
+	code *gojq.Code
+	// body of a range block
+	nodes []templateNode
+}
+
+// templateToken is a text or an action in braces of a template
+type templateToken struct {
+	text   string
+	action bool
+}
+
+// jsonPathPrelude defines functions making jq expressions fail like kubectl JSONPath
+// on missing keys and indexes instead of returning null
+const jsonPathPrelude = `
+def _field($k): if type == "object" and has($k) then .[$k] else error("\($k) is not found") end;
+def _index($i): if type != "array" then error("\(type) can't be indexed")
+	elif $i >= length or $i < -length then error("array index \($i) out of bounds")
+	else .[$i] end;
+def _all: if type == "array" or type == "object" then .[] else error("\(type) can't be iterated") end;
+def _descend($k): .. | select(type == "object" and has($k)) | .[$k];
+def _get($path): try getpath($path) catch null;
+`
+
+var (
+	jsonPathNameRe   = regexp.MustCompile(`^[\w-]+`)
+	jsonPathIndexRe  = regexp.MustCompile(`^-?\d+$`)
+	jsonPathFilterRe = regexp.MustCompile(`^(.+?)\s*(==|!=|<=|>=|<|>)\s*(.+)$`)
+)
+
+// ParseJSONPath parses a JSONPath template
+func ParseJSONPath(expr string) (*JSONPath, error) {
+	tokens, err := splitTemplate(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jsonpath expression %q: %w", expr, err)
+	}
+	var pos int
+	nodes, err := buildNodes(tokens, &pos, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jsonpath expression %q: %w", expr, err)
+	}
+	return &JSONPath{expr: expr, nodes: nodes}, nil
+}
+
+// Execute evaluates the template against v and writes the result followed
+// by a new line unless it already ends with one
+func (q *JSONPath) Execute(w io.Writer, v any) error {
+	data, err := normalize(v)
+	if err != nil {
+		return err
+	}
+	if items, ok := data.([]any); ok {
+		data = map[string]any{"items": items}
+	}
+
+	var buf bytes.Buffer
+	if err := execute(&buf, q.nodes, data); err != nil {
+		return fmt.Errorf("failed to evaluate jsonpath expression %q: %w", q.expr, err)
+	}
+	if strings.TrimSpace(buf.String()) == "" {
+		return errNoMatch(q.expr)
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// execute writes nodes evaluated against data to buf. Results of an expression
+// are separated by spaces, range blocks are written for every result.
+func execute(buf *bytes.Buffer, nodes []templateNode, data any) error {
+	for _, node := range nodes {
+		if node.kind == nodeText {
+			buf.WriteString(node.text)
+			continue
+		}
+
+		values, err := run(node.code, data)
+		if err != nil {
+			return err
+		}
+		if node.kind == nodeRange {
+			// a range over a single list or object iterates its items
+			if len(values) == 1 {
+				switch v := values[0].(type) {
+				case []any:
+					values = v
+				case map[string]any:
+					values = make([]any, 0, len(v))
+					for _, key := range slices.Sorted(maps.Keys(v)) {
+						values = append(values, v[key])
+					}
+				}
+			}
+			for _, v := range values {
+				if err := execute(buf, node.nodes, v); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		for i, v := range values {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			s, err := formatCompact(v)
+			if err != nil {
+				return err
+			}
+			buf.WriteString(s)
+		}
+	}
+	return nil
+}
+
+// run returns all outputs of code evaluated against data
+func run(code *gojq.Code, data any) ([]any, error) {
+	var values []any
+	iter := code.Run(data)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			return values, nil
+		}
+		if err, ok := v.(error); ok {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+}
+
+// formatCompact formats a value of an expression: strings are printed as is,
+// other values are printed as JSON in one line
+func formatCompact(v any) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// splitTemplate splits a template to texts and actions in braces
+func splitTemplate(expr string) ([]templateToken, error) {
+	var tokens []templateToken
+	for expr != "" {
+		start := strings.IndexByte(expr, '{')
+		if start < 0 {
+			tokens = append(tokens, templateToken{text: expr})
+			break
+		}
+		if start > 0 {
+			tokens = append(tokens, templateToken{text: expr[:start]})
+		}
+
+		end := indexClosing(expr[start+1:], '{', '}')
+		if end < 0 {
+			return nil, errors.New("unclosed action")
+		}
+		tokens = append(tokens, templateToken{text: expr[start+1 : start+1+end], action: true})
+		expr = expr[start+end+2:]
+	}
+	return tokens, nil
+}
+
+// indexClosing returns the index of close matching an already opened bracket in s,
+// brackets in quoted strings are skipped. It returns -1 if there is none.
+func indexClosing(s string, open, close byte) int {
+	var (
+		depth int
+		quote byte
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == open:
+			depth++
+		case c == close && depth == 0:
+			return i
+		case c == close:
+			depth--
+		}
+	}
+	return -1
+}
+
+// buildNodes builds nodes of tokens starting at pos until the end of the range block
+// if inRange is set or until the last token otherwise
+func buildNodes(tokens []templateToken, pos *int, inRange bool) ([]templateNode, error) {
+	var nodes []templateNode
+	for *pos < len(tokens) {
+		token := tokens[*pos]
+		*pos++
+		if !token.action {
+			nodes = append(nodes, templateNode{kind: nodeText, text: token.text})
+			continue
+		}
+
+		action := strings.TrimSpace(token.text)
+		switch {
+		case action == "end":
+			if !inRange {
+				return nil, errors.New("end without range")
+			}
+			return nodes, nil
+		case strings.HasPrefix(action, "range "):
+			code, err := compilePath(strings.TrimSpace(strings.TrimPrefix(action, "range ")))
+			if err != nil {
+				return nil, err
+			}
+			body, err := buildNodes(tokens, pos, true)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, templateNode{kind: nodeRange, code: code, nodes: body})
+		case strings.HasPrefix(action, `"`):
+			text, err := strconv.Unquote(action)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s", action)
+			}
+			nodes = append(nodes, templateNode{kind: nodeText, text: text})
+		default:
+			code, err := compilePath(action)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, templateNode{kind: nodeExpr, code: code})
+		}
+	}
+	if inRange {
+		return nil, errors.New("range without end")
+	}
+	return nodes, nil
+}
+
+// compilePath compiles a JSONPath expression translated to jq
+func compilePath(path string) (*gojq.Code, error) {
+	jq, err := translatePath(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := gojq.Parse(jsonPathPrelude + jq)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %s: %w", path, err)
+	}
+	return gojq.Compile(parsed)
+}
+
+// translatePath translates a JSONPath expression to jq, e.g. '.items[*].id'
+// to '. | _field("items") | _all | _field("id")'
+func translatePath(path string) (string, error) {
+	// both the root and the current item are the value the expression is evaluated against
+	p := path
+	if strings.HasPrefix(p, "$") || strings.HasPrefix(p, "@") {
+		p = p[1:]
+	}
+	parts := []string{"."}
+	for p != "" {
+		switch {
+		case strings.HasPrefix(p, ".."):
+			name := jsonPathNameRe.FindString(p[2:])
+			if name == "" {
+				return "", fmt.Errorf("invalid path %s: '..' must be followed by a key", path)
+			}
+			parts = append(parts, "_descend("+jsonString(name)+")")
+			p = p[2+len(name):]
+		case p[0] == '.':
+			name := jsonPathNameRe.FindString(p[1:])
+			if name != "" {
+				parts = append(parts, "_field("+jsonString(name)+")")
+			}
+			p = p[1+len(name):]
+		case p[0] == '[':
+			end := indexClosing(p[1:], '[', ']')
+			if end < 0 {
+				return "", fmt.Errorf("invalid path %s: unclosed '['", path)
+			}
+			part, err := translateSubscript(p[1 : end+1])
+			if err != nil {
+				return "", fmt.Errorf("invalid path %s: %w", path, err)
+			}
+			parts = append(parts, part)
+			p = p[end+2:]
+		default:
+			return "", fmt.Errorf("invalid path %s: unexpected %q", path, p[0])
+		}
+	}
+	return strings.Join(parts, " | "), nil
+}
+
+// translateSubscript translates the content of brackets to jq
+func translateSubscript(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "*":
+		return "_all", nil
+	case strings.HasPrefix(s, "?(") && strings.HasSuffix(s, ")"):
+		cond, err := translateFilter(strings.TrimSpace(s[2 : len(s)-1]))
+		if err != nil {
+			return "", err
+		}
+		return "_all | select(" + cond + ")", nil
+	case jsonPathIndexRe.MatchString(s):
+		return "_index(" + s + ")", nil
+	case strings.Contains(s, ":"):
+		bounds := strings.Split(s, ":")
+		if len(bounds) != 2 {
+			return "", fmt.Errorf("slice steps aren't supported: [%s]", s)
+		}
+		for _, b := range bounds {
+			if b != "" && !jsonPathIndexRe.MatchString(b) {
+				return "", fmt.Errorf("invalid slice [%s]", s)
+			}
+		}
+		if bounds[0] == "" && bounds[1] == "" {
+			return "_all", nil
+		}
+		return fmt.Sprintf("(if type == \"array\" then .[%s:%s][] else error(\"\\(type) can't be sliced\") end)", bounds[0], bounds[1]), nil
+	default:
+		key, err := unquoteKey(s)
+		if err != nil {
+			return "", err
+		}
+		return "_field(" + jsonString(key) + ")", nil
+	}
+}
+
+// translateFilter translates a filter condition like '@.status == "active"'
+// or '@.labels' to jq
+func translateFilter(cond string) (string, error) {
+	m := jsonPathFilterRe.FindStringSubmatch(cond)
+	if m == nil {
+		operand, err := translateOperand(cond)
+		if err != nil {
+			return "", err
+		}
+		return operand + " != null", nil
+	}
+
+	left, err := translateOperand(m[1])
+	if err != nil {
+		return "", err
+	}
+	right, err := translateOperand(m[3])
+	if err != nil {
+		return "", err
+	}
+	return left + " " + m[2] + " " + right, nil
+}
+
+// translateOperand translates '@' paths of fields and literals of filters to jq,
+// fields missing in an item are null
+func translateOperand(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "@":
+		return ".", nil
+	case strings.HasPrefix(s, "@."):
+		keys := strings.Split(s[2:], ".")
+		for i, key := range keys {
+			if jsonPathNameRe.FindString(key) != key {
+				return "", fmt.Errorf("invalid filter field %s", s)
+			}
+			keys[i] = jsonString(key)
+		}
+		return "_get([" + strings.Join(keys, ", ") + "])", nil
+	case s == "true" || s == "false" || s == "null":
+		return s, nil
+	case strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "'"):
+		key, err := unquoteKey(s)
+		if err != nil {
+			return "", err
+		}
+		return jsonString(key), nil
+	default:
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return "", fmt.Errorf("invalid filter operand %s", s)
+		}
+		return s, nil
+	}
+}
+
+// unquoteKey returns the content of a string in single or double quotes
+func unquoteKey(s string) (string, error) {
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return strings.ReplaceAll(s[1:len(s)-1], `\'`, `'`), nil
+	}
+	if len(s) >= 2 && s[0] == '"' {
+		if key, err := strconv.Unquote(s); err == nil {
+			return key, nil
+		}
+	}
+	return "", fmt.Errorf("invalid subscript [%s]", s)
+}
+
+// jsonString returns s as a JSON string literal, which is a valid jq string
+func jsonString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
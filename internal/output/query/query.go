@@ -0,0 +1,62 @@
+// Package query evaluates JSONPath and jq expressions used to extract values
+// from command output without external tools.
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Query is a compiled output expression
+type Query interface {
+	// Execute evaluates the expression against v and writes results to w.
+	// It fails if the expression matches nothing.
+	Execute(w io.Writer, v any) error
+}
+
+// normalize converts v to a generic JSON value, so expressions see the same fields
+// as the json output. Numbers are converted to int if they are integers or to
+// float64 otherwise.
+func normalize(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var result any
+	if err := dec.Decode(&result); err != nil {
+		return nil, err
+	}
+	return normalizeNumbers(result), nil
+}
+
+// normalizeNumbers replaces json.Number values with int or float64
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+	case map[string]any:
+		for k, item := range v {
+			v[k] = normalizeNumbers(item)
+		}
+	}
+	return v
+}
+
+// errNoMatch returns an error of an expression which matched nothing
+func errNoMatch(expr string) error {
+	return fmt.Errorf("expression %q matched nothing", expr)
+}
@@ -0,0 +1,247 @@
+package query
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+type testHost struct {
+	ID     string            `json:"id"`
+	Title  string            `json:"title"`
+	Status string            `json:"status"`
+	Rack   *int64            `json:"rack_id"`
+	Labels map[string]string `json:"labels"`
+}
+
+var (
+	rackID    = int64(10)
+	testHosts = []testHost{
+		{ID: "a1", Title: "web & db <01>", Status: "active", Rack: &rackID, Labels: map[string]string{"env": "prod"}},
+		{ID: "b2", Title: "web-02", Status: "init", Labels: map[string]string{"env": "dev"}},
+	}
+)
+
+func TestJSONPath(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expr           string
+		expectedOutput string
+		expectError    bool
+	}{
+		{
+			name:           "ids of all items",
+			expr:           "{.items[*].id}",
+			expectedOutput: "a1 b2\n",
+		},
+		{
+			name:           "field of an item by index",
+			expr:           "{.items[-1:].title}",
+			expectedOutput: "web-02\n",
+		},
+		{
+			name:           "special characters are printed as is",
+			expr:           "{$.items[0].title}",
+			expectedOutput: "web & db <01>\n",
+		},
+		{
+			name:           "range with text",
+			expr:           `{range .items[*]}{.id}{"\t"}{.labels.env}{"\n"}{end}`,
+			expectedOutput: "a1\tprod\nb2\tdev\n",
+		},
+		{
+			name:           "filter",
+			expr:           `{.items[?(@.status == "active")].id}`,
+			expectedOutput: "a1\n",
+		},
+		{
+			name:           "filter by number",
+			expr:           `{.items[?(@.rack_id >= 10)].id}`,
+			expectedOutput: "a1\n",
+		},
+		{
+			name:           "filter by existence",
+			expr:           `{.items[?(@.rack_id)].id}`,
+			expectedOutput: "a1\n",
+		},
+		{
+			name:           "quoted keys",
+			expr:           `{.items[1]['labels']["env"]}`,
+			expectedOutput: "dev\n",
+		},
+		{
+			name:           "nested range",
+			expr:           `{range .items[*]}{.id}:{range .labels}{@}{end};{end}`,
+			expectedOutput: "a1:prod;b2:dev;\n",
+		},
+		{
+			name:           "slice and recursive descent",
+			expr:           "ids: {.items[1:]..id}",
+			expectedOutput: "ids: b2\n",
+		},
+		{
+			name:           "object is printed as JSON",
+			expr:           "{.items[0].labels}",
+			expectedOutput: "{\"env\":\"prod\"}\n",
+		},
+		{
+			name:        "missing key",
+			expr:        "{.items[0].nope}",
+			expectError: true,
+		},
+		{
+			name:        "missing key of a list",
+			expr:        "{[0].nope}",
+			expectError: true,
+		},
+		{
+			name:        "index out of bounds",
+			expr:        "{.items[5].id}",
+			expectError: true,
+		},
+		{
+			name:        "slice step",
+			expr:        "{.items[0:2:1].id}",
+			expectError: true,
+		},
+		{
+			name:        "nothing matched",
+			expr:        `{.items[?(@.status == "deleted")].id}`,
+			expectError: true,
+		},
+		{
+			name:        "unclosed brace",
+			expr:        "{.items[*].id",
+			expectError: true,
+		},
+		{
+			name:        "range without end",
+			expr:        "{range .items[*]}{.id}",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			q, err := ParseJSONPath(tc.expr)
+			if err == nil {
+				var buf bytes.Buffer
+				err = q.Execute(&buf, testHosts)
+				if !tc.expectError {
+					g.Expect(buf.String()).To(Equal(tc.expectedOutput))
+				}
+			}
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
+
+func TestJSONPathExecutedTwice(t *testing.T) {
+	g := NewWithT(t)
+
+	q, err := ParseJSONPath(`{range .items[*]}{.id}{"\n"}{end}`)
+	g.Expect(err).To(BeNil())
+
+	for range 2 {
+		var buf bytes.Buffer
+		g.Expect(q.Execute(&buf, testHosts)).To(Succeed())
+		g.Expect(buf.String()).To(Equal("a1\nb2\n"))
+	}
+}
+
+func TestJQ(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expr           string
+		expectedOutput string
+		expectError    bool
+	}{
+		{
+			name:           "ids of all items",
+			expr:           ".[].id",
+			expectedOutput: "a1\nb2\n",
+		},
+		{
+			name:           "special characters are printed as is",
+			expr:           ".[0].title",
+			expectedOutput: "web & db <01>\n",
+		},
+		{
+			name:           "select with pipe",
+			expr:           `.[] | select(.status == "active" and .labels.env != "dev") | .id`,
+			expectedOutput: "a1\n",
+		},
+		{
+			name:           "object construction",
+			expr:           `.[1] | {id, env: .labels.env, rack: (.rack_id // "none")}`,
+			expectedOutput: "{\n  \"env\": \"dev\",\n  \"id\": \"b2\",\n  \"rack\": \"none\"\n}\n",
+		},
+		{
+			name:           "string interpolation",
+			expr:           `.[] | "\(.id): \(.title)"`,
+			expectedOutput: "a1: web & db <01>\nb2: web-02\n",
+		},
+		{
+			name:           "variables and reduce",
+			expr:           `"x" as $prefix | reduce .[] as $h (""; . + $prefix + $h.id)`,
+			expectedOutput: "xa1xb2\n",
+		},
+		{
+			name:           "csv",
+			expr:           `.[] | [.id, .status] | @csv`,
+			expectedOutput: "\"a1\",\"active\"\n\"b2\",\"init\"\n",
+		},
+		{
+			name:           "first and to_entries of an array",
+			expr:           `first(to_entries[] | select(.value.status == "init")) | .key`,
+			expectedOutput: "1\n",
+		},
+		{
+			name:        "nothing matched",
+			expr:        `.[] | select(.status == "deleted")`,
+			expectError: true,
+		},
+		{
+			name:        "runtime error",
+			expr:        ".[0].id + 1",
+			expectError: true,
+		},
+		{
+			name:        "unknown function",
+			expr:        ".[] | unknown",
+			expectError: true,
+		},
+		{
+			name:        "unclosed bracket",
+			expr:        ".[0",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			q, err := ParseJQ(tc.expr)
+			if err == nil {
+				var buf bytes.Buffer
+				err = q.Execute(&buf, testHosts)
+				if !tc.expectError {
+					g.Expect(buf.String()).To(Equal(tc.expectedOutput))
+				}
+			}
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(BeNil())
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/serverscom/srvctl/internal/client"
+)
+
+// FormatRequest formats API request which is not sent in dry run mode
+func (f *Formatter) FormatRequest(req *client.Request) error {
+	if f.IsStructured() {
+		return f.Format(req)
+	}
+
+	if _, err := fmt.Fprintf(f.writer, "%s %s\n", req.Method, req.URL); err != nil {
+		return err
+	}
+	if req.Body == nil {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(req.Body, "", "    ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = f.writer.Write(data)
+	return err
+}
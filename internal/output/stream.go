@@ -0,0 +1,146 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/serverscom/srvctl/internal/output/entities"
+)
+
+const (
+	// columnPadding is a gap between columns of the text output
+	columnPadding = 3
+)
+
+// streamOutputs can be written page by page as pages arrive
+var streamOutputs = []string{"text", "csv", "tsv", "ndjson"}
+
+// CanStream reports whether list items can be written page by page,
+// i.e. the output is a table without a template or page view
+func (f *Formatter) CanStream() bool {
+	return f.capture == nil && f.template == nil && f.templateStr == "" && !f.pageView &&
+		slices.Contains(streamOutputs, f.output)
+}
+
+// Stream writes list items page by page. Columns of the text output are aligned
+// within a page, or across pages by widths of the first page with --fixed-widths.
+type Stream struct {
+	f       *Formatter
+	fields  []entities.Field
+	csv     *csv.Writer
+	widths  []int
+	started bool
+}
+
+// NewStream creates a stream of items of the same type as v
+func (f *Formatter) NewStream(v any) (*Stream, error) {
+	entity, err := entities.Registry.GetEntityFromValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Stream{f: f}
+	if f.output == "ndjson" {
+		return s, nil
+	}
+
+	s.fields = f.getOrderedFields(entity)
+	switch f.output {
+	case "csv":
+		s.csv = csv.NewWriter(f.writer)
+	case "tsv":
+		s.csv = csv.NewWriter(f.writer)
+		s.csv.Comma = '\t'
+	}
+	return s, nil
+}
+
+// Write writes a page of items, the header is written with the first page
+func (s *Stream) Write(v any) error {
+	if s.f.output == "ndjson" {
+		if v == nil {
+			return nil
+		}
+		return s.f.formatNDJSON(v)
+	}
+
+	var rows [][]string
+	if !s.started && s.f.header {
+		headers := make([]string, 0, len(s.fields))
+		for _, field := range s.fields {
+			headers = append(headers, field.GetName())
+		}
+		rows = append(rows, headers)
+	}
+	s.started = true
+
+	if v != nil {
+		err := processValue(reflect.ValueOf(v), func(item any) error {
+			values, err := rowValues(item, s.fields)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, values)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	switch {
+	case s.csv != nil:
+		return s.csv.WriteAll(rows)
+	case s.f.fixedWidths:
+		return s.writeFixed(rows)
+	default:
+		w := tabwriter.NewWriter(s.f.writer, 0, 0, columnPadding, ' ', 0)
+		for _, row := range rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	}
+}
+
+// writeFixed writes rows padded to column widths of the first written rows,
+// longer values shift following columns
+func (s *Stream) writeFixed(rows [][]string) error {
+	if s.widths == nil {
+		s.widths = make([]int, len(s.fields))
+		for _, row := range rows {
+			for i, value := range row {
+				s.widths[i] = max(s.widths[i], utf8.RuneCountInString(value))
+			}
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range rows {
+		for i, value := range row {
+			b.WriteString(value)
+			// like tabwriter, the last column isn't padded
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", max(s.widths[i]-utf8.RuneCountInString(value), 0)+columnPadding))
+			}
+		}
+		b.WriteByte('\n')
+	}
+	_, err := fmt.Fprint(s.f.writer, b.String())
+	return err
+}
+
+// Close completes the stream, a header is written if there were no pages
+func (s *Stream) Close() error {
+	if !s.started {
+		return s.Write(nil)
+	}
+	return nil
+}
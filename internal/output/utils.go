@@ -169,28 +169,38 @@ func processValue(value reflect.Value, processor func(any) error) error {
 
 // formatRow formats a single row for the given item and fields.
 func (f *Formatter) formatRow(w io.Writer, item any, fields []entities.Field) error {
+	values, err := rowValues(item, fields)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, strings.Join(values, "\t"))
+	return nil
+}
+
+// rowValues returns values of the given fields rendered for list mode.
+func rowValues(item any, fields []entities.Field) ([]string, error) {
 	values := make([]string, 0, len(fields))
 
 	for _, field := range fields {
 		fieldValue, err := utils.GetFieldValue(item, field.GetPath())
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		var buf strings.Builder
 
 		if field.ListHandlerFunc == nil {
-			return fmt.Errorf("no ListHandlerFunc defined for field %s", field.Name)
+			return nil, fmt.Errorf("no ListHandlerFunc defined for field %s", field.Name)
 		}
 
 		if err := field.ListRender(&buf, fieldValue); err != nil {
-			return err
+			return nil, err
 		}
 		values = append(values, buf.String())
 	}
 
-	fmt.Fprintln(w, strings.Join(values, "\t"))
-	return nil
+	return values, nil
 }
 
 // formatPageView formats the given data as a page view.
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/jmespath/go-jmespath"
@@ -28,3 +29,24 @@ func GetFieldValue(item any, jsonPath string) (any, error) {
 	}
 	return result, nil
 }
+
+// GetFieldStringValue returns the value of a given field for an item formatted
+// as a string. Nil pointers are returned as an empty string.
+func GetFieldStringValue(item any, jsonPath string) (string, error) {
+	v, err := GetFieldValue(item, jsonPath)
+	if err != nil {
+		return "", err
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return "", nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return "", nil
+	}
+	return fmt.Sprint(rv.Interface()), nil
+}
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"bytes"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -74,3 +75,94 @@ func TestApplyFieldDeltas(t *testing.T) {
 	g.Expect(applyFieldDeltas([]string{"A", "B"}, []string{"-C"})).To(Equal([]string{"A", "B"}))
 	g.Expect(applyFieldDeltas([]string{"A", "B"}, []string{"-A", "+A"})).To(Equal([]string{"B", "A"}))
 }
+
+func TestFormatDelimited(t *testing.T) {
+	keys := []serverscom.SSHKey{
+		{Name: "plain", Fingerprint: "fp1"},
+		{Name: `with "quotes", comma`, Fingerprint: "fp\t2"},
+	}
+
+	testCases := []struct {
+		name           string
+		formatter      Formatter
+		expectedOutput string
+	}{
+		{
+			name:      "csv with default fields",
+			formatter: Formatter{output: "csv", cmdName: "list", header: true},
+			expectedOutput: "Name,Fingerprint\n" +
+				"plain,fp1\n" +
+				"\"with \"\"quotes\"\", comma\",fp\t2\n",
+		},
+		{
+			name:      "tsv with default fields",
+			formatter: Formatter{output: "tsv", cmdName: "list", header: true},
+			expectedOutput: "Name\tFingerprint\n" +
+				"plain\tfp1\n" +
+				"\"with \"\"quotes\"\", comma\"\t\"fp\t2\"\n",
+		},
+		{
+			name:      "csv with field deltas",
+			formatter: Formatter{output: "csv", cmdName: "list", header: true, fieldsToShow: []string{"-Fingerprint"}},
+			expectedOutput: "Name\n" +
+				"plain\n" +
+				"\"with \"\"quotes\"\", comma\"\n",
+		},
+		{
+			name:      "tsv without header",
+			formatter: Formatter{output: "tsv", cmdName: "list", fieldsToShow: []string{"Fingerprint"}},
+			expectedOutput: "fp1\n" +
+				"\"fp\t2\"\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var buf bytes.Buffer
+			f := tc.formatter
+			f.writer = &buf
+
+			g.Expect(f.Format(keys)).To(Succeed())
+			g.Expect(buf.String()).To(Equal(tc.expectedOutput))
+		})
+	}
+}
+
+func TestFormatNDJSON(t *testing.T) {
+	testCases := []struct {
+		name           string
+		value          any
+		expectedOutput string
+	}{
+		{
+			name:  "list",
+			value: []serverscom.SSHKey{{Name: "a & b", Fingerprint: "fp1"}, {Name: "c", Fingerprint: "fp2"}},
+			expectedOutput: `{"name":"a & b","fingerprint":"fp1","labels":null,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}` + "\n" +
+				`{"name":"c","fingerprint":"fp2","labels":null,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}` + "\n",
+		},
+		{
+			name:           "single object",
+			value:          &serverscom.SSHKey{Name: "a", Fingerprint: "fp1", Labels: map[string]string{"env": "prod"}},
+			expectedOutput: `{"name":"a","fingerprint":"fp1","labels":{"env":"prod"},"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}` + "\n",
+		},
+		{
+			name:           "empty list",
+			value:          []serverscom.SSHKey{},
+			expectedOutput: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var buf bytes.Buffer
+			f := Formatter{output: "ndjson", writer: &buf}
+
+			g.Expect(f.Format(tc.value)).To(Succeed())
+			g.Expect(buf.String()).To(Equal(tc.expectedOutput))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package output
+
+import (
+	"encoding/json"
+)
+
+const (
+	WatchEventAdded    = "added"
+	WatchEventModified = "modified"
+	WatchEventDeleted  = "deleted"
+)
+
+// WatchEvent represents a change of a record in watch mode
+type WatchEvent struct {
+	Type   string `json:"type"`
+	Object any    `json:"object"`
+}
+
+// FormatWatchEvent formats an event as a single line of JSON
+func (f *Formatter) FormatWatchEvent(event WatchEvent) error {
+	enc := json.NewEncoder(f.writer)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(event)
+}
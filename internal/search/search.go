@@ -0,0 +1,396 @@
+// Package search finds resources of different kinds by a name fragment,
+// an IP address or a label.
+package search
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+
+	serverscom "github.com/serverscom/serverscom-go-client/pkg"
+)
+
+// patternMatch is reported as the matched field of resources found by the API
+// search_pattern by a field which is not matched locally
+const patternMatch = "search_pattern"
+
+// Result represents a resource matching the search term
+type Result struct {
+	Kind     string `json:"kind"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Location string `json:"location"`
+	Field    string `json:"field"`
+	Value    string `json:"value"`
+}
+
+// Options holds options of search
+type Options struct {
+	// Kinds limits search to these kinds, all kinds are searched if empty
+	Kinds []string
+	// Name matches the term against names only, kinds supporting it are
+	// filtered by the API search_pattern instead of being listed completely
+	Name bool
+}
+
+// field is a named value a term is matched against
+type field struct {
+	name  string
+	value string
+	// cidr fields also match addresses within the network
+	cidr bool
+}
+
+// candidate is a resource with values a term is matched against
+type candidate struct {
+	kind     string
+	id       string
+	name     string
+	location string
+	fields   []field
+	labels   map[string]string
+}
+
+// searcher finds candidates of a kind
+type searcher struct {
+	kind string
+	// searchPattern reports whether the API filters the collection by name
+	searchPattern bool
+	list          func(ctx context.Context, client *serverscom.Client, pattern string) ([]candidate, error)
+}
+
+// newSearcher returns a searcher listing collection items converted by fn
+func newSearcher[T any](kind string, searchPattern bool, collection func(client *serverscom.Client) serverscom.Collection[T], fn func(item T) candidate) searcher {
+	return searcher{
+		kind:          kind,
+		searchPattern: searchPattern,
+		list: func(ctx context.Context, client *serverscom.Client, pattern string) ([]candidate, error) {
+			col := collection(client)
+			if pattern != "" {
+				col = col.SetParam("search_pattern", pattern)
+			}
+			items, err := col.Collect(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make([]candidate, 0, len(items))
+			for _, item := range items {
+				c := fn(item)
+				if c.kind == "" {
+					c.kind = kind
+				}
+				result = append(result, c)
+			}
+			return result, nil
+		},
+	}
+}
+
+// hostKinds maps host types to srvctl commands
+var hostKinds = map[string]string{
+	"dedicated_server":          "ebm",
+	"kubernetes_baremetal_node": "kbm",
+	"sbm_server":                "sbm",
+}
+
+// searchers contains all searched kinds, names match srvctl commands
+var searchers = []searcher{
+	newSearcher("hosts", true,
+		func(client *serverscom.Client) serverscom.Collection[serverscom.Host] {
+			return client.Hosts.Collection()
+		},
+		func(h serverscom.Host) candidate {
+			return candidate{
+				kind:     cmp.Or(hostKinds[h.Type], h.Type),
+				id:       h.ID,
+				name:     h.Title,
+				location: h.LocationCode,
+				fields: []field{
+					{name: "title", value: h.Title},
+					{name: "public_ipv4_address", value: deref(h.PublicIPv4Address)},
+					{name: "private_ipv4_address", value: deref(h.PrivateIPv4Address)},
+					{name: "oob_ipv4_address", value: h.OOBIPv4Address},
+				},
+				labels: h.Labels,
+			}
+		},
+	),
+	newSearcher("cloud-instances", false,
+		func(client *serverscom.Client) serverscom.Collection[serverscom.CloudComputingInstance] {
+			return client.CloudComputingInstances.Collection()
+		},
+		func(i serverscom.CloudComputingInstance) candidate {
+			return candidate{
+				id:       i.ID,
+				name:     i.Name,
+				location: i.RegionCode,
+				fields: []field{
+					{name: "name", value: i.Name},
+					{name: "public_ipv4_address", value: deref(i.PublicIPv4Address)},
+					{name: "private_ipv4_address", value: deref(i.PrivateIPv4Address)},
+					{name: "local_ipv4_address", value: deref(i.LocalIPv4Address)},
+					{name: "public_ipv6_address", value: deref(i.PublicIPv6Address)},
+				},
+				labels: i.Labels,
+			}
+		},
+	),
+	newSearcher("lb", true,
+		func(client *serverscom.Client) serverscom.Collection[serverscom.LoadBalancer] {
+			return client.LoadBalancers.Collection()
+		},
+		func(lb serverscom.LoadBalancer) candidate {
+			fields := []field{{name: "name", value: lb.Name}}
+			for _, address := range lb.ExternalAddresses {
+				fields = append(fields, field{name: "external_addresses", value: address})
+			}
+			return candidate{
+				id:       lb.ID,
+				name:     lb.Name,
+				location: lb.LocationCode,
+				fields:   fields,
+				labels:   lb.Labels,
+			}
+		},
+	),
+	newSearcher("l2-segments", false,
+		func(client *serverscom.Client) serverscom.Collection[serverscom.L2Segment] {
+			return client.L2Segments.Collection()
+		},
+		func(s serverscom.L2Segment) candidate {
+			return candidate{
+				id:       s.ID,
+				name:     s.Name,
+				location: s.LocationGroupCode,
+				fields:   []field{{name: "name", value: s.Name}},
+				labels:   s.Labels,
+			}
+		},
+	),
+	newSearcher("network-pools", true,
+		func(client *serverscom.Client) serverscom.Collection[serverscom.NetworkPool] {
+			return client.NetworkPools.Collection()
+		},
+		func(p serverscom.NetworkPool) candidate {
+			return candidate{
+				id:       p.ID,
+				name:     deref(p.Title),
+				location: strings.Join(p.LocationCodes, ","),
+				fields: []field{
+					{name: "title", value: deref(p.Title)},
+					{name: "cidr", value: p.CIDR, cidr: true},
+				},
+				labels: p.Labels,
+			}
+		},
+	),
+	newSearcher("ssl", true,
+		func(client *serverscom.Client) serverscom.Collection[serverscom.SSLCertificate] {
+			return client.SSLCertificates.Collection()
+		},
+		func(c serverscom.SSLCertificate) candidate {
+			fields := []field{{name: "name", value: c.Name}}
+			for _, domain := range c.DomainNames {
+				fields = append(fields, field{name: "domain_names", value: domain})
+			}
+			return candidate{
+				id:     c.ID,
+				name:   c.Name,
+				fields: fields,
+				labels: c.Labels,
+			}
+		},
+	),
+	newSearcher("rbs", false,
+		func(client *serverscom.Client) serverscom.Collection[serverscom.RemoteBlockStorageVolume] {
+			return client.RemoteBlockStorageVolumes.Collection()
+		},
+		func(v serverscom.RemoteBlockStorageVolume) candidate {
+			return candidate{
+				id:       v.ID,
+				name:     v.Name,
+				location: v.RegionCode,
+				fields: []field{
+					{name: "name", value: v.Name},
+					{name: "public_ipv4_address", value: v.PublicIPv4Address},
+					{name: "private_ipv4_address", value: v.PrivateIPv4Address},
+					{name: "local_ipv4_address", value: v.LocalIPv4Address},
+					{name: "public_ipv6_address", value: v.PublicIPv6Address},
+				},
+				labels: v.Labels,
+			}
+		},
+	),
+}
+
+// KindNames returns names of searched kinds
+func KindNames() []string {
+	names := make([]string, 0, len(searchers))
+	for _, s := range searchers {
+		names = append(names, s.kind)
+	}
+	return names
+}
+
+// Search looks for the term in all kinds at the same time. All resources are
+// listed and matched locally by names, IP addresses and labels, so a term like
+// 'prod' finds resources labeled with env=prod. With Options.Name the term is
+// a name fragment, kinds supporting it are filtered by the API search_pattern
+// and other kinds are matched locally by names. The first error cancels
+// requests in flight and is returned.
+func Search(ctx context.Context, client *serverscom.Client, term string, opts Options) ([]Result, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, fmt.Errorf("search term can't be empty")
+	}
+	for _, kind := range opts.Kinds {
+		if !slices.Contains(KindNames(), kind) {
+			return nil, fmt.Errorf("unknown kind %q, supported kinds: %s", kind, strings.Join(KindNames(), ", "))
+		}
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		wg      sync.WaitGroup
+		results = make([][]Result, len(searchers))
+	)
+	for i, s := range searchers {
+		if len(opts.Kinds) > 0 && !slices.Contains(opts.Kinds, s.kind) {
+			continue
+		}
+		wg.Go(func() {
+			found, err := s.search(ctx, client, term, opts.Name)
+			if err != nil {
+				cancel(fmt.Errorf("failed to search %s: %w", s.kind, err))
+				return
+			}
+			results[i] = found
+		})
+	}
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+	found := slices.Concat(results...)
+	if found == nil {
+		found = []Result{}
+	}
+	return found, nil
+}
+
+// search returns candidates of the searcher kind matching the term, or only
+// their names if nameOnly is set
+func (s *searcher) search(ctx context.Context, client *serverscom.Client, term string, nameOnly bool) ([]Result, error) {
+	var pattern string
+	if nameOnly && s.searchPattern {
+		pattern = term
+	}
+
+	candidates, err := s.list(ctx, client, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Result
+	for _, c := range candidates {
+		var (
+			name, value string
+			ok          bool
+		)
+		if nameOnly && pattern == "" {
+			name, value, ok = c.matchName(term)
+		} else {
+			name, value, ok = c.match(term)
+		}
+		if !ok {
+			if pattern == "" {
+				continue
+			}
+			// the API matched a field which isn't known locally
+			name = patternMatch
+		}
+		result = append(result, Result{
+			Kind:     c.kind,
+			ID:       c.id,
+			Name:     c.name,
+			Location: c.location,
+			Field:    name,
+			Value:    value,
+		})
+	}
+	return result, nil
+}
+
+// match returns the first field of the candidate matching the term. Fields match
+// case-insensitively by a substring, CIDR fields also match addresses within
+// the network. Labels match by 'key=value' or by a substring of a key or a value.
+func (c *candidate) match(term string) (string, string, bool) {
+	lower := strings.ToLower(term)
+	addr, addrErr := netip.ParseAddr(term)
+
+	for _, f := range c.fields {
+		if f.value == "" {
+			continue
+		}
+		if strings.Contains(strings.ToLower(f.value), lower) {
+			return f.name, f.value, true
+		}
+		if f.cidr && addrErr == nil {
+			if prefix, err := netip.ParsePrefix(f.value); err == nil && prefix.Contains(addr) {
+				return f.name, f.value, true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(c.labels))
+	for key := range c.labels {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		value := c.labels[key]
+		pair := key + "=" + value
+		if strings.Contains(term, "=") {
+			if pair == term {
+				return "labels." + key, value, true
+			}
+			continue
+		}
+		if strings.Contains(strings.ToLower(key), lower) || strings.Contains(strings.ToLower(value), lower) {
+			return "labels." + key, value, true
+		}
+	}
+	return "", "", false
+}
+
+// nameFields are fields holding names or titles of candidates
+var nameFields = []string{"name", "title"}
+
+// matchName returns the name field of the candidate if it contains the term
+// case-insensitively
+func (c *candidate) matchName(term string) (string, string, bool) {
+	lower := strings.ToLower(term)
+	for _, f := range c.fields {
+		if slices.Contains(nameFields, f.name) && f.value != "" && strings.Contains(strings.ToLower(f.value), lower) {
+			return f.name, f.value, true
+		}
+	}
+	return "", "", false
+}
+
+// deref returns the value of s or an empty string if it's nil
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
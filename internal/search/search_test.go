@@ -0,0 +1,127 @@
+package search
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCandidateMatch(t *testing.T) {
+	c := candidate{
+		fields: []field{
+			{name: "title", value: "Web-01"},
+			{name: "public_ipv4_address", value: "192.0.2.10"},
+			{name: "cidr", value: "10.0.0.0/24", cidr: true},
+			{name: "private_ipv4_address"},
+		},
+		labels: map[string]string{"env": "prod", "team": "web"},
+	}
+
+	testCases := []struct {
+		name          string
+		term          string
+		expectedField string
+		expectedValue string
+		expectMatch   bool
+	}{
+		{
+			name:          "name ignoring case",
+			term:          "web",
+			expectedField: "title",
+			expectedValue: "Web-01",
+			expectMatch:   true,
+		},
+		{
+			name:          "partial ip",
+			term:          "192.0.2.",
+			expectedField: "public_ipv4_address",
+			expectedValue: "192.0.2.10",
+			expectMatch:   true,
+		},
+		{
+			name:          "address within network",
+			term:          "10.0.0.42",
+			expectedField: "cidr",
+			expectedValue: "10.0.0.0/24",
+			expectMatch:   true,
+		},
+		{
+			name:          "label pair",
+			term:          "env=prod",
+			expectedField: "labels.env",
+			expectedValue: "prod",
+			expectMatch:   true,
+		},
+		{
+			name:          "label value",
+			term:          "pro",
+			expectedField: "labels.env",
+			expectedValue: "prod",
+			expectMatch:   true,
+		},
+		{
+			name: "label pair with another value",
+			term: "env=stage",
+		},
+		{
+			name: "address outside network",
+			term: "10.0.1.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			field, value, ok := c.match(tc.term)
+			g.Expect(ok).To(Equal(tc.expectMatch))
+			g.Expect(field).To(Equal(tc.expectedField))
+			g.Expect(value).To(Equal(tc.expectedValue))
+		})
+	}
+}
+
+func TestCandidateMatchName(t *testing.T) {
+	c := candidate{
+		fields: []field{
+			{name: "name", value: "Web-LB"},
+			{name: "external_addresses", value: "192.0.2.10"},
+		},
+		labels: map[string]string{"team": "web"},
+	}
+
+	testCases := []struct {
+		name          string
+		term          string
+		expectedField string
+		expectedValue string
+		expectMatch   bool
+	}{
+		{
+			name:          "name ignoring case",
+			term:          "web",
+			expectedField: "name",
+			expectedValue: "Web-LB",
+			expectMatch:   true,
+		},
+		{
+			name: "address",
+			term: "192.0.2.10",
+		},
+		{
+			name: "label pair",
+			term: "team=web",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			field, value, ok := c.matchName(tc.term)
+			g.Expect(ok).To(Equal(tc.expectMatch))
+			g.Expect(field).To(Equal(tc.expectedField))
+			g.Expect(value).To(Equal(tc.expectedValue))
+		})
+	}
+}